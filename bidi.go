@@ -0,0 +1,157 @@
+package i18n
+
+import (
+	"html"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// TextDirection is the direction in which the text of a locale is written.
+type TextDirection int
+
+const (
+	// LeftToRight is the direction of Latin, Cyrillic, Chinese and most other
+	// scripts.
+	LeftToRight TextDirection = iota
+	// RightToLeft is the direction of Arabic, Hebrew and Thaana scripts.
+	RightToLeft
+)
+
+// BidiIsolation selects how arguments substituted for placeholders are
+// isolated from the surrounding text in right-to-left locales, so that e.g.
+// a Latin user name doesn't scramble the Arabic message around it.
+type BidiIsolation int
+
+const (
+	// BidiControls surrounds arguments with the Unicode FIRST STRONG ISOLATE
+	// and POP DIRECTIONAL ISOLATE characters, U+2068 and U+2069.
+	BidiControls BidiIsolation = iota
+	// BidiHTML surrounds arguments with <bdi> elements, for messages which
+	// are rendered as HTML. Arguments are HTML-escaped, the text of messages
+	// isn't.
+	BidiHTML
+	// BidiNone leaves arguments as is.
+	BidiNone
+)
+
+// rtlScripts are the right-to-left scripts of the languages in CLDR.
+var rtlScripts = map[string]bool{
+	"Adlm": true,
+	"Arab": true,
+	"Hebr": true,
+	"Mand": true,
+	"Nkoo": true,
+	"Rohg": true,
+	"Samr": true,
+	"Syrc": true,
+	"Thaa": true,
+}
+
+var (
+	bidiIsolation = BidiControls
+	mirrorLayout  = strings.NewReplacer("left", "right", "right", "left", "Left", "Right", "Right", "Left", "ltr", "rtl", "rtl", "ltr")
+)
+
+// SetBidiIsolation selects how T isolates the arguments of messages in
+// right-to-left locales. It defaults to BidiControls. Messages formatted with
+// fmt.Sprintf have their string arguments isolated, others are left as is so
+// that verbs such as %d still apply. TPlain always uses BidiControls, unless
+// isolation is BidiNone.
+func SetBidiIsolation(isolation BidiIsolation) {
+	trMutex.Lock()
+	defer trMutex.Unlock()
+	bidiIsolation = isolation
+}
+
+// Direction returns the direction of the script of the current locale, e.g.
+// RightToLeft for ar, fa and he.
+func Direction() TextDirection {
+	return currentLocalizer().Direction()
+}
+
+// Mirror mirrors a layout hint such as "left", "margin-right" or "ltr" if
+// the current locale is right-to-left, e.g. to "right", "margin-left" and
+// "rtl", and returns it as is otherwise.
+func Mirror(hint string) string {
+	return currentLocalizer().Mirror(hint)
+}
+
+// Direction returns the text direction of the locale of l.
+func (l *Localizer) Direction() TextDirection {
+	return directionOf(l.tag())
+}
+
+// Mirror mirrors hint like the package level Mirror, for the locale of l.
+func (l *Localizer) Mirror(hint string) string {
+	if l.Direction() == RightToLeft {
+		return mirrorLayout.Replace(hint)
+	}
+	return hint
+}
+
+// String returns the value of the HTML dir attribute for d, "ltr" or "rtl".
+func (d TextDirection) String() string {
+	if d == RightToLeft {
+		return "rtl"
+	}
+	return "ltr"
+}
+
+// Start returns the side text starts from, "left" or "right".
+func (d TextDirection) Start() string {
+	if d == RightToLeft {
+		return "right"
+	}
+	return "left"
+}
+
+// End returns the side text ends at, "right" or "left".
+func (d TextDirection) End() string {
+	if d == RightToLeft {
+		return "left"
+	}
+	return "right"
+}
+
+// directionOf returns the direction of the script of tag, or of the most
+// likely script of its language.
+func directionOf(tag language.Tag) TextDirection {
+	script, _ := tag.Script()
+	if rtlScripts[script.String()] {
+		return RightToLeft
+	}
+	return LeftToRight
+}
+
+// isolate isolates s from the surrounding text.
+func isolate(s string, isolation BidiIsolation) string {
+	switch isolation {
+	case BidiControls:
+		return "\u2068" + s + "\u2069"
+	case BidiHTML:
+		return "<bdi>" + s + "</bdi>"
+	default:
+		return s
+	}
+}
+
+// isolateArgs returns the arguments of a message formatted with fmt.Sprintf in
+// the locale of tag, with its strings isolated as selected by isolation if the
+// locale is right-to-left.
+func isolateArgs(tag language.Tag, args []interface{}, isolation BidiIsolation) []interface{} {
+	if isolation == BidiNone || directionOf(tag) != RightToLeft {
+		return args
+	}
+	isolated := make([]interface{}, len(args))
+	for i, arg := range args {
+		if s, ok := arg.(string); ok {
+			if isolation == BidiHTML {
+				s = html.EscapeString(s)
+			}
+			arg = isolate(s, isolation)
+		}
+		isolated[i] = arg
+	}
+	return isolated
+}
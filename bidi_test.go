@@ -0,0 +1,66 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestDirection(t *testing.T) {
+	for _, c := range []struct {
+		locale   string
+		expected TextDirection
+	}{
+		{"en-US", LeftToRight},
+		{"zh-CN", LeftToRight},
+		{"ar", RightToLeft},
+		{"ar-EG", RightToLeft},
+		{"fa", RightToLeft},
+		{"he", RightToLeft},
+		{"ur", RightToLeft},
+		{"dv", RightToLeft},
+		{"tr", LeftToRight},
+	} {
+		assert.Equal(t, c.expected, directionOf(language.Make(c.locale)), c.locale)
+	}
+	assert.Equal(t, "rtl", RightToLeft.String())
+	assert.Equal(t, "ltr", LeftToRight.String())
+	assert.Equal(t, "right", RightToLeft.Start())
+	assert.Equal(t, "left", RightToLeft.End())
+	assert.Equal(t, "left", LeftToRight.Start())
+}
+
+func TestMirror(t *testing.T) {
+	ar := &Localizer{locale: "ar"}
+	en := &Localizer{locale: "en-US"}
+	assert.Equal(t, "right", ar.Mirror("left"))
+	assert.Equal(t, "margin-left: 4px; text-align: right", ar.Mirror("margin-right: 4px; text-align: left"))
+	assert.Equal(t, "rtl", ar.Mirror("ltr"))
+	assert.Equal(t, "margin-right", en.Mirror("margin-right"))
+	assert.Equal(t, RightToLeft, ar.Direction())
+}
+
+func TestBidiIsolation(t *testing.T) {
+	defer SetBidiIsolation(BidiControls)
+	he := &Localizer{locale: "he", messages: map[string]string{
+		"SHARED": "{name} שיתף את {0}",
+		"HELLO":  "שלום %s",
+		"COUNT":  "%s קבצים: %d",
+	}}
+	en := &Localizer{locale: "en-US", messages: map[string]string{"SHARED": "{name} shared {0}"}}
+	assert.Equal(t, "\u2068alice\u2069 שיתף את \u2068notes.txt\u2069", he.T("SHARED", "notes.txt", Params{"name": "alice"}))
+	assert.Equal(t, "שלום \u2068alice\u2069", he.T("HELLO", "alice"), "should isolate the strings of printf messages")
+	assert.Equal(t, "\u2068notes\u2069 קבצים: 3", he.T("COUNT", "notes", 3), "should leave other printf arguments as is")
+	assert.Equal(t, "alice shared notes.txt", en.T("SHARED", "notes.txt", Params{"name": "alice"}))
+
+	SetBidiIsolation(BidiHTML)
+	assert.Equal(t, "<bdi>alice</bdi> שיתף את <bdi>notes.txt</bdi>", he.T("SHARED", "notes.txt", Params{"name": "alice"}))
+	assert.Equal(t, "<bdi>&lt;b&gt;alice</bdi> שיתף את <bdi>notes.txt</bdi>", he.T("SHARED", "notes.txt", Params{"name": "<b>alice"}), "should escape arguments")
+	assert.Equal(t, "שלום <bdi>&lt;b&gt;alice</bdi>", he.T("HELLO", "<b>alice"))
+	assert.Equal(t, "\u2068<b>alice\u2069 שיתף את \u2068notes.txt\u2069", he.TPlain("SHARED", "notes.txt", Params{"name": "<b>alice"}), "should isolate plain text with controls")
+	assert.Equal(t, "שלום \u2068<b>alice\u2069", he.TPlain("HELLO", "<b>alice"))
+	SetBidiIsolation(BidiNone)
+	assert.Equal(t, "alice שיתף את notes.txt", he.T("SHARED", "notes.txt", Params{"name": "alice"}))
+	assert.Equal(t, "שלום alice", he.T("HELLO", "alice"))
+}
@@ -0,0 +1,602 @@
+package i18n
+
+// calendar holds the CLDR Gregorian calendar data of a language or locale.
+type calendar struct {
+	months     [12]string
+	monthsAbbr [12]string
+	// stand-alone month names, used for L, where they differ from months,
+	// e.g. the nominative in Russian
+	standaloneMonths     [12]string
+	standaloneMonthsAbbr [12]string
+	weekdays             [7]string
+	weekdaysAbbr         [7]string
+	am, pm               string
+	// date patterns, from full to short
+	dates [4]string
+	// patterns combining a date, {1}, with a time, {0}, from full to short
+	dateTime [4]string
+	// short time patterns with a 12 and a 24-hour clock
+	hm, Hm string
+	// date patterns by skeleton
+	skeletons map[string]string
+	// the GMT format, e.g. "GMT{0}", and the format of GMT itself
+	gmt, gmtZero string
+	// zones are the long names of the zones of zoneNames in the language,
+	// nil to use the GMT format
+	zones map[zone]string
+	// zoneAbbrs enables the abbreviations of zones, such as PST, in short
+	// names
+	zoneAbbrs bool
+}
+
+var calendars = map[string]*calendar{
+	"ar": {
+		months:       [12]string{"يناير", "فبراير", "مارس", "أبريل", "مايو", "يونيو", "يوليو", "أغسطس", "سبتمبر", "أكتوبر", "نوفمبر", "ديسمبر"},
+		monthsAbbr:   [12]string{"يناير", "فبراير", "مارس", "أبريل", "مايو", "يونيو", "يوليو", "أغسطس", "سبتمبر", "أكتوبر", "نوفمبر", "ديسمبر"},
+		weekdays:     [7]string{"الأحد", "الاثنين", "الثلاثاء", "الأربعاء", "الخميس", "الجمعة", "السبت"},
+		weekdaysAbbr: [7]string{"الأحد", "الاثنين", "الثلاثاء", "الأربعاء", "الخميس", "الجمعة", "السبت"},
+		am:           "ص",
+		pm:           "م",
+		dates:        [4]string{"EEEE، d MMMM y", "d MMMM y", "dd‏/MM‏/y", "d‏/M‏/y"},
+		dateTime:     [4]string{"{1} في {0}", "{1} في {0}", "{1} {0}", "{1} {0}"},
+		hm:           "h:mm a",
+		Hm:           "HH:mm",
+		skeletons: map[string]string{
+			"yMd": "d‏/M‏/y", "yMMM": "MMM y", "yMMMd": "d MMM y", "yMMMEd": "E، d MMM y", "yMMMM": "MMMM y",
+			"MMMd": "d MMM", "MMMEd": "E، d MMM", "MMMMd": "d MMMM", "Md": "d/‏M",
+		},
+		gmt:     "غرينتش{0}",
+		gmtZero: "غرينتش",
+	},
+	"cs": {
+		months:               [12]string{"ledna", "února", "března", "dubna", "května", "června", "července", "srpna", "září", "října", "listopadu", "prosince"},
+		monthsAbbr:           [12]string{"led", "úno", "bře", "dub", "kvě", "čvn", "čvc", "srp", "zář", "říj", "lis", "pro"},
+		standaloneMonths:     [12]string{"leden", "únor", "březen", "duben", "květen", "červen", "červenec", "srpen", "září", "říjen", "listopad", "prosinec"},
+		standaloneMonthsAbbr: [12]string{"led", "úno", "bře", "dub", "kvě", "čvn", "čvc", "srp", "zář", "říj", "lis", "pro"},
+		weekdays:             [7]string{"neděle", "pondělí", "úterý", "středa", "čtvrtek", "pátek", "sobota"},
+		weekdaysAbbr:         [7]string{"ne", "po", "út", "st", "čt", "pá", "so"},
+		am:                   "dop.",
+		pm:                   "odp.",
+		dates:                [4]string{"EEEE d. MMMM y", "d. MMMM y", "d. M. y", "dd.MM.yy"},
+		dateTime:             [4]string{"{1} 'v' {0}", "{1} 'v' {0}", "{1} {0}", "{1} {0}"},
+		hm:                   "h:mm a",
+		Hm:                   "H:mm",
+		skeletons: map[string]string{
+			"yMd": "d. M. y", "yMMM": "LLLL y", "yMMMd": "d. M. y", "yMMMEd": "E d. M. y", "yMMMM": "LLLL y",
+			"MMMd": "d. M.", "MMMEd": "E d. M.", "MMMMd": "d. MMMM", "Md": "d. M.",
+		},
+		gmt:     "GMT{0}",
+		gmtZero: "GMT",
+	},
+	"da": {
+		months:       [12]string{"januar", "februar", "marts", "april", "maj", "juni", "juli", "august", "september", "oktober", "november", "december"},
+		monthsAbbr:   [12]string{"jan.", "feb.", "mar.", "apr.", "maj", "jun.", "jul.", "aug.", "sep.", "okt.", "nov.", "dec."},
+		weekdays:     [7]string{"søndag", "mandag", "tirsdag", "onsdag", "torsdag", "fredag", "lørdag"},
+		weekdaysAbbr: [7]string{"søn.", "man.", "tirs.", "ons.", "tors.", "fre.", "lør."},
+		am:           "AM",
+		pm:           "PM",
+		dates:        [4]string{"EEEE 'den' d. MMMM y", "d. MMMM y", "d. MMM y", "dd.MM.y"},
+		dateTime:     [4]string{"{1} 'kl'. {0}", "{1} 'kl'. {0}", "{1} {0}", "{1} {0}"},
+		hm:           "h.mm a",
+		Hm:           "HH.mm",
+		skeletons: map[string]string{
+			"yMd": "d.M.y", "yMMM": "MMM y", "yMMMd": "d. MMM y", "yMMMEd": "E d. MMM y", "yMMMM": "MMMM y",
+			"MMMd": "d. MMM", "MMMEd": "E d. MMM", "MMMMd": "d. MMMM", "Md": "d.M",
+		},
+		gmt:     "GMT{0}",
+		gmtZero: "GMT",
+	},
+	"de": {
+		months:       [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		monthsAbbr:   [12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez."},
+		weekdays:     [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		weekdaysAbbr: [7]string{"So.", "Mo.", "Di.", "Mi.", "Do.", "Fr.", "Sa."},
+		am:           "AM",
+		pm:           "PM",
+		dates:        [4]string{"EEEE, d. MMMM y", "d. MMMM y", "dd.MM.y", "dd.MM.yy"},
+		dateTime:     [4]string{"{1} 'um' {0}", "{1} 'um' {0}", "{1}, {0}", "{1}, {0}"},
+		hm:           "h:mm a",
+		Hm:           "HH:mm",
+		skeletons: map[string]string{
+			"yMd": "d.M.y", "yMMM": "MMM y", "yMMMd": "d. MMM y", "yMMMEd": "E, d. MMM y", "yMMMM": "MMMM y",
+			"MMMd": "d. MMM", "MMMEd": "E, d. MMM", "MMMMd": "d. MMMM", "Md": "d.M.",
+		},
+		gmt:     "GMT{0}",
+		gmtZero: "GMT",
+	},
+	"en": {
+		months:       [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		monthsAbbr:   [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		weekdays:     [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		weekdaysAbbr: [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		am:           "AM",
+		pm:           "PM",
+		dates:        [4]string{"EEEE, MMMM d, y", "MMMM d, y", "MMM d, y", "M/d/yy"},
+		dateTime:     [4]string{"{1} 'at' {0}", "{1} 'at' {0}", "{1}, {0}", "{1}, {0}"},
+		hm:           "h:mm a",
+		Hm:           "HH:mm",
+		skeletons: map[string]string{
+			"yMd": "M/d/y", "yMMM": "MMM y", "yMMMd": "MMM d, y", "yMMMEd": "E, MMM d, y", "yMMMM": "MMMM y",
+			"MMMd": "MMM d", "MMMEd": "E, MMM d", "MMMMd": "MMMM d", "Md": "M/d",
+		},
+		gmt:       "GMT{0}",
+		gmtZero:   "GMT",
+		zones:     zoneNames["en"],
+		zoneAbbrs: true,
+	},
+	"es": {
+		months:       [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		monthsAbbr:   [12]string{"ene.", "feb.", "mar.", "abr.", "may.", "jun.", "jul.", "ago.", "sept.", "oct.", "nov.", "dic."},
+		weekdays:     [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		weekdaysAbbr: [7]string{"dom.", "lun.", "mar.", "mié.", "jue.", "vie.", "sáb."},
+		am:           "a. m.",
+		pm:           "p. m.",
+		dates:        [4]string{"EEEE, d 'de' MMMM 'de' y", "d 'de' MMMM 'de' y", "d MMM y", "d/M/yy"},
+		dateTime:     [4]string{"{1}, {0}", "{1}, {0}", "{1}, {0}", "{1}, {0}"},
+		hm:           "h:mm a",
+		Hm:           "H:mm",
+		skeletons: map[string]string{
+			"yMd": "d/M/y", "yMMM": "MMM y", "yMMMd": "d MMM y", "yMMMEd": "EEE, d MMM y", "yMMMM": "MMMM 'de' y",
+			"MMMd": "d MMM", "MMMEd": "E, d MMM", "MMMMd": "d 'de' MMMM", "Md": "d/M",
+		},
+		gmt:     "GMT{0}",
+		gmtZero: "GMT",
+	},
+	"fa": {
+		months:       [12]string{"ژانویه", "فوریه", "مارس", "آوریل", "مه", "ژوئن", "ژوئیه", "اوت", "سپتامبر", "اکتبر", "نوامبر", "دسامبر"},
+		monthsAbbr:   [12]string{"ژانویه", "فوریه", "مارس", "آوریل", "مه", "ژوئن", "ژوئیه", "اوت", "سپتامبر", "اکتبر", "نوامبر", "دسامبر"},
+		weekdays:     [7]string{"یکشنبه", "دوشنبه", "سه‌شنبه", "چهارشنبه", "پنجشنبه", "جمعه", "شنبه"},
+		weekdaysAbbr: [7]string{"یکشنبه", "دوشنبه", "سه‌شنبه", "چهارشنبه", "پنجشنبه", "جمعه", "شنبه"},
+		am:           "ق.ظ.",
+		pm:           "ب.ظ.",
+		dates:        [4]string{"EEEE d MMMM y", "d MMMM y", "d MMM y", "y/M/d"},
+		dateTime:     [4]string{"{1}، ساعت {0}", "{1}، ساعت {0}", "{1}، {0}", "{1}، {0}"},
+		hm:           "h:mm a",
+		Hm:           "H:mm",
+		skeletons: map[string]string{
+			"yMd": "y/M/d", "yMMM": "MMM y", "yMMMd": "d MMM y", "yMMMEd": "E d MMM y", "yMMMM": "MMMM y",
+			"MMMd": "d LLL", "MMMEd": "E d LLL", "MMMMd": "d LLLL", "Md": "M/d",
+		},
+		gmt:     "{0} گرینویچ",
+		gmtZero: "گرینویچ",
+	},
+	"fi": {
+		months:               [12]string{"tammikuuta", "helmikuuta", "maaliskuuta", "huhtikuuta", "toukokuuta", "kesäkuuta", "heinäkuuta", "elokuuta", "syyskuuta", "lokakuuta", "marraskuuta", "joulukuuta"},
+		monthsAbbr:           [12]string{"tammik.", "helmik.", "maalisk.", "huhtik.", "toukok.", "kesäk.", "heinäk.", "elok.", "syysk.", "lokak.", "marrask.", "jouluk."},
+		standaloneMonths:     [12]string{"tammikuu", "helmikuu", "maaliskuu", "huhtikuu", "toukokuu", "kesäkuu", "heinäkuu", "elokuu", "syyskuu", "lokakuu", "marraskuu", "joulukuu"},
+		standaloneMonthsAbbr: [12]string{"tammi", "helmi", "maalis", "huhti", "touko", "kesä", "heinä", "elo", "syys", "loka", "marras", "joulu"},
+		weekdays:             [7]string{"sunnuntai", "maanantai", "tiistai", "keskiviikko", "torstai", "perjantai", "lauantai"},
+		weekdaysAbbr:         [7]string{"su", "ma", "ti", "ke", "to", "pe", "la"},
+		am:                   "ap.",
+		pm:                   "ip.",
+		dates:                [4]string{"cccc d. MMMM y", "d. MMMM y", "d.M.y", "d.M.y"},
+		dateTime:             [4]string{"{1} 'klo' {0}", "{1} 'klo' {0}", "{1} 'klo' {0}", "{1} {0}"},
+		hm:                   "h.mm a",
+		Hm:                   "H.mm",
+		skeletons: map[string]string{
+			"yMd": "d.M.y", "yMMM": "LLL y", "yMMMd": "d.M.y", "yMMMEd": "E d.M.y", "yMMMM": "LLLL y",
+			"MMMd": "d.M.", "MMMEd": "ccc d.M.", "MMMMd": "d. MMMM", "Md": "d.M.",
+		},
+		gmt:     "UTC{0}",
+		gmtZero: "UTC",
+	},
+	"fr": {
+		months:       [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		monthsAbbr:   [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		weekdays:     [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		weekdaysAbbr: [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+		am:           "AM",
+		pm:           "PM",
+		dates:        [4]string{"EEEE d MMMM y", "d MMMM y", "d MMM y", "dd/MM/y"},
+		dateTime:     [4]string{"{1} 'à' {0}", "{1} 'à' {0}", "{1} {0}", "{1} {0}"},
+		hm:           "h:mm a",
+		Hm:           "HH:mm",
+		skeletons: map[string]string{
+			"yMd": "dd/MM/y", "yMMM": "MMM y", "yMMMd": "d MMM y", "yMMMEd": "E d MMM y", "yMMMM": "MMMM y",
+			"MMMd": "d MMM", "MMMEd": "E d MMM", "MMMMd": "d MMMM", "Md": "dd/MM",
+		},
+		gmt:     "UTC{0}",
+		gmtZero: "UTC",
+	},
+	"he": {
+		months:       [12]string{"ינואר", "פברואר", "מרץ", "אפריל", "מאי", "יוני", "יולי", "אוגוסט", "ספטמבר", "אוקטובר", "נובמבר", "דצמבר"},
+		monthsAbbr:   [12]string{"ינו׳", "פבר׳", "מרץ", "אפר׳", "מאי", "יוני", "יולי", "אוג׳", "ספט׳", "אוק׳", "נוב׳", "דצמ׳"},
+		weekdays:     [7]string{"יום ראשון", "יום שני", "יום שלישי", "יום רביעי", "יום חמישי", "יום שישי", "יום שבת"},
+		weekdaysAbbr: [7]string{"יום א׳", "יום ב׳", "יום ג׳", "יום ד׳", "יום ה׳", "יום ו׳", "שבת"},
+		am:           "לפנה״צ",
+		pm:           "אחה״צ",
+		dates:        [4]string{"EEEE, d בMMMM y", "d בMMMM y", "d בMMM y", "d.M.y"},
+		dateTime:     [4]string{"{1} 'בשעה' {0}", "{1} 'בשעה' {0}", "{1}, {0}", "{1}, {0}"},
+		hm:           "h:mm a",
+		Hm:           "H:mm",
+		skeletons: map[string]string{
+			"yMd": "d.M.y", "yMMM": "MMM y", "yMMMd": "d בMMM y", "yMMMEd": "E, d בMMM y", "yMMMM": "MMMM y",
+			"MMMd": "d בMMM", "MMMEd": "E, d בMMM", "MMMMd": "d בMMMM", "Md": "d.M",
+		},
+		gmt:     "GMT{0}",
+		gmtZero: "GMT",
+	},
+	"hi": {
+		months:       [12]string{"जनवरी", "फ़रवरी", "मार्च", "अप्रैल", "मई", "जून", "जुलाई", "अगस्त", "सितंबर", "अक्तूबर", "नवंबर", "दिसंबर"},
+		monthsAbbr:   [12]string{"जन॰", "फ़र॰", "मार्च", "अप्रैल", "मई", "जून", "जुल॰", "अग॰", "सित॰", "अक्तू॰", "नव॰", "दिस॰"},
+		weekdays:     [7]string{"रविवार", "सोमवार", "मंगलवार", "बुधवार", "गुरुवार", "शुक्रवार", "शनिवार"},
+		weekdaysAbbr: [7]string{"रवि", "सोम", "मंगल", "बुध", "गुरु", "शुक्र", "शनि"},
+		am:           "am",
+		pm:           "pm",
+		dates:        [4]string{"EEEE, d MMMM y", "d MMMM y", "d MMM y", "d/M/yy"},
+		dateTime:     [4]string{"{1} 'को' {0}", "{1} 'को' {0}", "{1}, {0}", "{1}, {0}"},
+		hm:           "h:mm a",
+		Hm:           "HH:mm",
+		skeletons: map[string]string{
+			"yMd": "d/M/y", "yMMM": "MMM y", "yMMMd": "d MMM y", "yMMMEd": "E, d MMM y", "yMMMM": "MMMM y",
+			"MMMd": "d MMM", "MMMEd": "E, d MMM", "MMMMd": "d MMMM", "Md": "d/M",
+		},
+		gmt:     "GMT{0}",
+		gmtZero: "GMT",
+	},
+	"it": {
+		months:       [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+		monthsAbbr:   [12]string{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"},
+		weekdays:     [7]string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+		weekdaysAbbr: [7]string{"dom", "lun", "mar", "mer", "gio", "ven", "sab"},
+		am:           "AM",
+		pm:           "PM",
+		dates:        [4]string{"EEEE d MMMM y", "d MMMM y", "d MMM y", "dd/MM/yy"},
+		dateTime:     [4]string{"{1} {0}", "{1} {0}", "{1}, {0}", "{1}, {0}"},
+		hm:           "h:mm a",
+		Hm:           "HH:mm",
+		skeletons: map[string]string{
+			"yMd": "d/M/y", "yMMM": "MMM y", "yMMMd": "d MMM y", "yMMMEd": "EEE d MMM y", "yMMMM": "MMMM y",
+			"MMMd": "d MMM", "MMMEd": "EEE d MMM", "MMMMd": "d MMMM", "Md": "d/M",
+		},
+		gmt:     "GMT{0}",
+		gmtZero: "GMT",
+	},
+	"ja": {
+		months:       [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		monthsAbbr:   [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		weekdays:     [7]string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+		weekdaysAbbr: [7]string{"日", "月", "火", "水", "木", "金", "土"},
+		am:           "午前",
+		pm:           "午後",
+		dates:        [4]string{"y年M月d日EEEE", "y年M月d日", "y/MM/dd", "y/MM/dd"},
+		dateTime:     [4]string{"{1} {0}", "{1} {0}", "{1} {0}", "{1} {0}"},
+		hm:           "aK:mm",
+		Hm:           "H:mm",
+		skeletons: map[string]string{
+			"yMd": "y/M/d", "yMMM": "y年M月", "yMMMd": "y年M月d日", "yMMMEd": "y年M月d日(E)", "yMMMM": "y年M月",
+			"MMMd": "M月d日", "MMMEd": "M月d日(E)", "MMMMd": "M月d日", "Md": "M/d",
+		},
+		gmt:     "GMT{0}",
+		gmtZero: "GMT",
+	},
+	"ko": {
+		months:       [12]string{"1월", "2월", "3월", "4월", "5월", "6월", "7월", "8월", "9월", "10월", "11월", "12월"},
+		monthsAbbr:   [12]string{"1월", "2월", "3월", "4월", "5월", "6월", "7월", "8월", "9월", "10월", "11월", "12월"},
+		weekdays:     [7]string{"일요일", "월요일", "화요일", "수요일", "목요일", "금요일", "토요일"},
+		weekdaysAbbr: [7]string{"일", "월", "화", "수", "목", "금", "토"},
+		am:           "오전",
+		pm:           "오후",
+		dates:        [4]string{"y년 MMMM d일 EEEE", "y년 MMMM d일", "y. M. d.", "yy. M. d."},
+		dateTime:     [4]string{"{1} {0}", "{1} {0}", "{1} {0}", "{1} {0}"},
+		hm:           "a h:mm",
+		Hm:           "HH:mm",
+		skeletons: map[string]string{
+			"yMd": "y. M. d.", "yMMM": "y년 MMM", "yMMMd": "y년 MMM d일", "yMMMEd": "y년 MMM d일 (E)", "yMMMM": "y년 MMMM",
+			"MMMd": "MMM d일", "MMMEd": "MMM d일 (E)", "MMMMd": "MMMM d일", "Md": "M. d.",
+		},
+		gmt:     "GMT{0}",
+		gmtZero: "GMT",
+	},
+	"nb": {
+		months:       [12]string{"januar", "februar", "mars", "april", "mai", "juni", "juli", "august", "september", "oktober", "november", "desember"},
+		monthsAbbr:   [12]string{"jan.", "feb.", "mar.", "apr.", "mai", "jun.", "jul.", "aug.", "sep.", "okt.", "nov.", "des."},
+		weekdays:     [7]string{"søndag", "mandag", "tirsdag", "onsdag", "torsdag", "fredag", "lørdag"},
+		weekdaysAbbr: [7]string{"søn.", "man.", "tir.", "ons.", "tor.", "fre.", "lør."},
+		am:           "a.m.",
+		pm:           "p.m.",
+		dates:        [4]string{"EEEE d. MMMM y", "d. MMMM y", "d. MMM y", "dd.MM.y"},
+		dateTime:     [4]string{"{1} 'kl'. {0}", "{1} 'kl'. {0}", "{1}, {0}", "{1}, {0}"},
+		hm:           "h:mm a",
+		Hm:           "HH:mm",
+		skeletons: map[string]string{
+			"yMd": "d.M.y", "yMMM": "MMM y", "yMMMd": "d. MMM y", "yMMMEd": "E d. MMM y", "yMMMM": "MMMM y",
+			"MMMd": "d. MMM", "MMMEd": "E d. MMM", "MMMMd": "d. MMMM", "Md": "d.M.",
+		},
+		gmt:     "GMT{0}",
+		gmtZero: "GMT",
+	},
+	"nl": {
+		months:       [12]string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"},
+		monthsAbbr:   [12]string{"jan", "feb", "mrt", "apr", "mei", "jun", "jul", "aug", "sep", "okt", "nov", "dec"},
+		weekdays:     [7]string{"zondag", "maandag", "dinsdag", "woensdag", "donderdag", "vrijdag", "zaterdag"},
+		weekdaysAbbr: [7]string{"zo", "ma", "di", "wo", "do", "vr", "za"},
+		am:           "a.m.",
+		pm:           "p.m.",
+		dates:        [4]string{"EEEE d MMMM y", "d MMMM y", "d MMM y", "dd-MM-y"},
+		dateTime:     [4]string{"{1} 'om' {0}", "{1} 'om' {0}", "{1} {0}", "{1} {0}"},
+		hm:           "h:mm a",
+		Hm:           "HH:mm",
+		skeletons: map[string]string{
+			"yMd": "d-M-y", "yMMM": "MMM y", "yMMMd": "d MMM y", "yMMMEd": "E d MMM y", "yMMMM": "MMMM y",
+			"MMMd": "d MMM", "MMMEd": "E d MMM", "MMMMd": "d MMMM", "Md": "d-M",
+		},
+		gmt:     "GMT{0}",
+		gmtZero: "GMT",
+	},
+	"pl": {
+		months:               [12]string{"stycznia", "lutego", "marca", "kwietnia", "maja", "czerwca", "lipca", "sierpnia", "września", "października", "listopada", "grudnia"},
+		monthsAbbr:           [12]string{"sty", "lut", "mar", "kwi", "maj", "cze", "lip", "sie", "wrz", "paź", "lis", "gru"},
+		standaloneMonths:     [12]string{"styczeń", "luty", "marzec", "kwiecień", "maj", "czerwiec", "lipiec", "sierpień", "wrzesień", "październik", "listopad", "grudzień"},
+		standaloneMonthsAbbr: [12]string{"sty", "lut", "mar", "kwi", "maj", "cze", "lip", "sie", "wrz", "paź", "lis", "gru"},
+		weekdays:             [7]string{"niedziela", "poniedziałek", "wtorek", "środa", "czwartek", "piątek", "sobota"},
+		weekdaysAbbr:         [7]string{"niedz.", "pon.", "wt.", "śr.", "czw.", "pt.", "sob."},
+		am:                   "AM",
+		pm:                   "PM",
+		dates:                [4]string{"EEEE, d MMMM y", "d MMMM y", "d MMM y", "d.MM.y"},
+		dateTime:             [4]string{"{1} {0}", "{1} {0}", "{1}, {0}", "{1}, {0}"},
+		hm:                   "h:mm a",
+		Hm:                   "HH:mm",
+		skeletons: map[string]string{
+			"yMd": "d.MM.y", "yMMM": "LLL y", "yMMMd": "d MMM y", "yMMMEd": "E, d MMM y", "yMMMM": "LLLL y",
+			"MMMd": "d MMM", "MMMEd": "E, d MMM", "MMMMd": "d MMMM", "Md": "d.MM",
+		},
+		gmt:     "GMT{0}",
+		gmtZero: "GMT",
+	},
+	"pt": {
+		months:       [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		monthsAbbr:   [12]string{"jan.", "fev.", "mar.", "abr.", "mai.", "jun.", "jul.", "ago.", "set.", "out.", "nov.", "dez."},
+		weekdays:     [7]string{"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado"},
+		weekdaysAbbr: [7]string{"dom.", "seg.", "ter.", "qua.", "qui.", "sex.", "sáb."},
+		am:           "AM",
+		pm:           "PM",
+		dates:        [4]string{"EEEE, d 'de' MMMM 'de' y", "d 'de' MMMM 'de' y", "d 'de' MMM 'de' y", "dd/MM/y"},
+		dateTime:     [4]string{"{1} {0}", "{1} {0}", "{1} {0}", "{1} {0}"},
+		hm:           "h:mm a",
+		Hm:           "HH:mm",
+		skeletons: map[string]string{
+			"yMd": "dd/MM/y", "yMMM": "MMM 'de' y", "yMMMd": "d 'de' MMM 'de' y", "yMMMEd": "E, d 'de' MMM 'de' y", "yMMMM": "MMMM 'de' y",
+			"MMMd": "d 'de' MMM", "MMMEd": "E, d 'de' MMM", "MMMMd": "d 'de' MMMM", "Md": "d/M",
+		},
+		gmt:     "GMT{0}",
+		gmtZero: "GMT",
+	},
+	"ru": {
+		months:               [12]string{"января", "февраля", "марта", "апреля", "мая", "июня", "июля", "августа", "сентября", "октября", "ноября", "декабря"},
+		monthsAbbr:           [12]string{"янв.", "февр.", "мар.", "апр.", "мая", "июн.", "июл.", "авг.", "сент.", "окт.", "нояб.", "дек."},
+		standaloneMonths:     [12]string{"январь", "февраль", "март", "апрель", "май", "июнь", "июль", "август", "сентябрь", "октябрь", "ноябрь", "декабрь"},
+		standaloneMonthsAbbr: [12]string{"янв.", "февр.", "март", "апр.", "май", "июнь", "июль", "авг.", "сент.", "окт.", "нояб.", "дек."},
+		weekdays:             [7]string{"воскресенье", "понедельник", "вторник", "среда", "четверг", "пятница", "суббота"},
+		weekdaysAbbr:         [7]string{"вс", "пн", "вт", "ср", "чт", "пт", "сб"},
+		am:                   "AM",
+		pm:                   "PM",
+		dates:                [4]string{"EEEE, d MMMM y 'г'.", "d MMMM y 'г'.", "d MMM y 'г'.", "dd.MM.y"},
+		dateTime:             [4]string{"{1}, {0}", "{1}, {0}", "{1}, {0}", "{1}, {0}"},
+		hm:                   "h:mm a",
+		Hm:                   "HH:mm",
+		skeletons: map[string]string{
+			"yMd": "dd.MM.y", "yMMM": "LLL y 'г'.", "yMMMd": "d MMM y 'г'.", "yMMMEd": "ccc, d MMM y 'г'.", "yMMMM": "LLLL y 'г'.",
+			"MMMd": "d MMM", "MMMEd": "ccc, d MMM", "MMMMd": "d MMMM", "Md": "dd.MM",
+		},
+		gmt:     "GMT{0}",
+		gmtZero: "GMT",
+	},
+	"sv": {
+		months:       [12]string{"januari", "februari", "mars", "april", "maj", "juni", "juli", "augusti", "september", "oktober", "november", "december"},
+		monthsAbbr:   [12]string{"jan.", "feb.", "mars", "apr.", "maj", "juni", "juli", "aug.", "sep.", "okt.", "nov.", "dec."},
+		weekdays:     [7]string{"söndag", "måndag", "tisdag", "onsdag", "torsdag", "fredag", "lördag"},
+		weekdaysAbbr: [7]string{"sön", "mån", "tis", "ons", "tors", "fre", "lör"},
+		am:           "fm",
+		pm:           "em",
+		dates:        [4]string{"EEEE d MMMM y", "d MMMM y", "d MMM y", "y-MM-dd"},
+		dateTime:     [4]string{"{1} {0}", "{1} {0}", "{1} {0}", "{1} {0}"},
+		hm:           "h:mm a",
+		Hm:           "HH:mm",
+		skeletons: map[string]string{
+			"yMd": "y-MM-dd", "yMMM": "MMM y", "yMMMd": "d MMM y", "yMMMEd": "E d MMM y", "yMMMM": "MMMM y",
+			"MMMd": "d MMM", "MMMEd": "E d MMM", "MMMMd": "d MMMM", "Md": "d/M",
+		},
+		gmt:     "GMT{0}",
+		gmtZero: "GMT",
+	},
+	"tr": {
+		months:       [12]string{"Ocak", "Şubat", "Mart", "Nisan", "Mayıs", "Haziran", "Temmuz", "Ağustos", "Eylül", "Ekim", "Kasım", "Aralık"},
+		monthsAbbr:   [12]string{"Oca", "Şub", "Mar", "Nis", "May", "Haz", "Tem", "Ağu", "Eyl", "Eki", "Kas", "Ara"},
+		weekdays:     [7]string{"Pazar", "Pazartesi", "Salı", "Çarşamba", "Perşembe", "Cuma", "Cumartesi"},
+		weekdaysAbbr: [7]string{"Paz", "Pzt", "Sal", "Çar", "Per", "Cum", "Cmt"},
+		am:           "ÖÖ",
+		pm:           "ÖS",
+		dates:        [4]string{"d MMMM y EEEE", "d MMMM y", "d MMM y", "d.MM.y"},
+		dateTime:     [4]string{"{1} {0}", "{1} {0}", "{1} {0}", "{1} {0}"},
+		hm:           "a h:mm",
+		Hm:           "HH:mm",
+		skeletons: map[string]string{
+			"yMd": "dd.MM.y", "yMMM": "MMM y", "yMMMd": "d MMM y", "yMMMEd": "d MMM y E", "yMMMM": "MMMM y",
+			"MMMd": "d MMM", "MMMEd": "d MMMM E", "MMMMd": "d MMMM", "Md": "d/M",
+		},
+		gmt:     "GMT{0}",
+		gmtZero: "GMT",
+	},
+	"uk": {
+		months:               [12]string{"січня", "лютого", "березня", "квітня", "травня", "червня", "липня", "серпня", "вересня", "жовтня", "листопада", "грудня"},
+		monthsAbbr:           [12]string{"січ.", "лют.", "бер.", "квіт.", "трав.", "черв.", "лип.", "серп.", "вер.", "жовт.", "лист.", "груд."},
+		standaloneMonths:     [12]string{"січень", "лютий", "березень", "квітень", "травень", "червень", "липень", "серпень", "вересень", "жовтень", "листопад", "грудень"},
+		standaloneMonthsAbbr: [12]string{"січ.", "лют.", "бер.", "квіт.", "трав.", "черв.", "лип.", "серп.", "вер.", "жовт.", "лист.", "груд."},
+		weekdays:             [7]string{"неділя", "понеділок", "вівторок", "середа", "четвер", "пʼятниця", "субота"},
+		weekdaysAbbr:         [7]string{"нд", "пн", "вт", "ср", "чт", "пт", "сб"},
+		am:                   "дп",
+		pm:                   "пп",
+		dates:                [4]string{"EEEE, d MMMM y 'р'.", "d MMMM y 'р'.", "d MMM y 'р'.", "dd.MM.yy"},
+		dateTime:             [4]string{"{1} 'о' {0}", "{1} 'о' {0}", "{1}, {0}", "{1}, {0}"},
+		hm:                   "h:mm a",
+		Hm:                   "HH:mm",
+		skeletons: map[string]string{
+			"yMd": "dd.MM.y", "yMMM": "LLL y 'р'.", "yMMMd": "d MMM y 'р'.", "yMMMEd": "EEE, d MMM y 'р'.", "yMMMM": "LLLL y 'р'.",
+			"MMMd": "d MMM", "MMMEd": "E, d MMM", "MMMMd": "d MMMM", "Md": "dd.MM",
+		},
+		gmt:     "GMT{0}",
+		gmtZero: "GMT",
+	},
+	"vi": {
+		months:       [12]string{"tháng 1", "tháng 2", "tháng 3", "tháng 4", "tháng 5", "tháng 6", "tháng 7", "tháng 8", "tháng 9", "tháng 10", "tháng 11", "tháng 12"},
+		monthsAbbr:   [12]string{"thg 1", "thg 2", "thg 3", "thg 4", "thg 5", "thg 6", "thg 7", "thg 8", "thg 9", "thg 10", "thg 11", "thg 12"},
+		weekdays:     [7]string{"Chủ Nhật", "Thứ Hai", "Thứ Ba", "Thứ Tư", "Thứ Năm", "Thứ Sáu", "Thứ Bảy"},
+		weekdaysAbbr: [7]string{"CN", "Th 2", "Th 3", "Th 4", "Th 5", "Th 6", "Th 7"},
+		am:           "SA",
+		pm:           "CH",
+		dates:        [4]string{"EEEE, d MMMM, y", "d MMMM, y", "d MMM, y", "dd/MM/y"},
+		dateTime:     [4]string{"{0} {1}", "{0} {1}", "{0} {1}", "{0} {1}"},
+		hm:           "h:mm a",
+		Hm:           "HH:mm",
+		skeletons: map[string]string{
+			"yMd": "d/M/y", "yMMM": "MMM y", "yMMMd": "d MMM, y", "yMMMEd": "E, d MMM, y", "yMMMM": "MMMM 'năm' y",
+			"MMMd": "d MMM", "MMMEd": "E, d MMM", "MMMMd": "d MMMM", "Md": "d/M",
+		},
+		gmt:     "GMT{0}",
+		gmtZero: "GMT",
+	},
+	"zh": {
+		months:       [12]string{"一月", "二月", "三月", "四月", "五月", "六月", "七月", "八月", "九月", "十月", "十一月", "十二月"},
+		monthsAbbr:   [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		weekdays:     [7]string{"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"},
+		weekdaysAbbr: [7]string{"周日", "周一", "周二", "周三", "周四", "周五", "周六"},
+		am:           "上午",
+		pm:           "下午",
+		dates:        [4]string{"y年M月d日EEEE", "y年M月d日", "y年M月d日", "y/M/d"},
+		dateTime:     [4]string{"{1} {0}", "{1} {0}", "{1} {0}", "{1} {0}"},
+		hm:           "ah:mm",
+		Hm:           "HH:mm",
+		skeletons: map[string]string{
+			"yMd": "y/M/d", "yMMM": "y年M月", "yMMMd": "y年M月d日", "yMMMEd": "y年M月d日E", "yMMMM": "y年M月",
+			"MMMd": "M月d日", "MMMEd": "M月d日E", "MMMMd": "M月d日", "Md": "M/d",
+		},
+		gmt:     "GMT{0}",
+		gmtZero: "GMT",
+		zones:   zoneNames["zh"],
+	},
+}
+
+func init() {
+	gb := *calendars["en"]
+	gb.am, gb.pm = "am", "pm"
+	gb.dates = [4]string{"EEEE, d MMMM y", "d MMMM y", "d MMM y", "dd/MM/y"}
+	gb.skeletons = map[string]string{
+		"yMd": "dd/MM/y", "yMMM": "MMM y", "yMMMd": "d MMM y", "yMMMEd": "E, d MMM y", "yMMMM": "MMMM y",
+		"MMMd": "d MMM", "MMMEd": "E d MMM", "MMMMd": "d MMMM", "Md": "dd/MM",
+	}
+	calendars["en-GB"] = &gb
+}
+
+// hour12Regions are the regions preferring a 12-hour clock, per the CLDR time
+// data.
+var hour12Regions = map[string]bool{
+	"AE": true, "AU": true, "BD": true, "BH": true, "CA": true, "CO": true,
+	"DO": true, "EG": true, "GT": true, "HK": true, "HN": true, "IN": true,
+	"IQ": true, "JO": true, "KR": true, "KW": true, "LB": true, "LY": true,
+	"MO": true, "MX": true, "MY": true, "NI": true, "NZ": true, "OM": true,
+	"PA": true, "PH": true, "PK": true, "PR": true, "QA": true, "SA": true,
+	"SD": true, "SV": true, "SY": true, "TW": true, "US": true, "VE": true,
+	"YE": true,
+}
+
+// zone identifies a time zone by its abbreviation and offset in seconds, as
+// abbreviations such as "CST" and "IST" are shared by several zones.
+type zone struct {
+	abbr   string
+	offset int
+}
+
+// zoneNames are the CLDR names of the most common time zones, by language.
+var zoneNames = map[string]map[zone]string{
+	"en": {
+		{"UTC", 0}:       "Coordinated Universal Time",
+		{"GMT", 0}:       "Greenwich Mean Time",
+		{"WET", 0}:       "Western European Standard Time",
+		{"WEST", 3600}:   "Western European Summer Time",
+		{"BST", 3600}:    "British Summer Time",
+		{"IST", 3600}:    "Irish Standard Time",
+		{"CET", 3600}:    "Central European Standard Time",
+		{"CEST", 7200}:   "Central European Summer Time",
+		{"EET", 7200}:    "Eastern European Standard Time",
+		{"EEST", 10800}:  "Eastern European Summer Time",
+		{"SAST", 7200}:   "South Africa Standard Time",
+		{"IST", 7200}:    "Israel Standard Time",
+		{"IDT", 10800}:   "Israel Daylight Time",
+		{"MSK", 10800}:   "Moscow Standard Time",
+		{"PKT", 18000}:   "Pakistan Standard Time",
+		{"IST", 19800}:   "India Standard Time",
+		{"WIB", 25200}:   "Western Indonesia Time",
+		{"CST", 28800}:   "China Standard Time",
+		{"HKT", 28800}:   "Hong Kong Standard Time",
+		{"AWST", 28800}:  "Australian Western Standard Time",
+		{"JST", 32400}:   "Japan Standard Time",
+		{"KST", 32400}:   "Korean Standard Time",
+		{"ACST", 34200}:  "Australian Central Standard Time",
+		{"ACDT", 37800}:  "Australian Central Daylight Time",
+		{"AEST", 36000}:  "Australian Eastern Standard Time",
+		{"AEDT", 39600}:  "Australian Eastern Daylight Time",
+		{"NZST", 43200}:  "New Zealand Standard Time",
+		{"NZDT", 46800}:  "New Zealand Daylight Time",
+		{"HST", -36000}:  "Hawaii-Aleutian Standard Time",
+		{"AKST", -32400}: "Alaska Standard Time",
+		{"AKDT", -28800}: "Alaska Daylight Time",
+		{"PST", -28800}:  "Pacific Standard Time",
+		{"PDT", -25200}:  "Pacific Daylight Time",
+		{"MST", -25200}:  "Mountain Standard Time",
+		{"MDT", -21600}:  "Mountain Daylight Time",
+		{"CST", -21600}:  "Central Standard Time",
+		{"CDT", -18000}:  "Central Daylight Time",
+		{"EST", -18000}:  "Eastern Standard Time",
+		{"EDT", -14400}:  "Eastern Daylight Time",
+		{"AST", -14400}:  "Atlantic Standard Time",
+		{"ADT", -10800}:  "Atlantic Daylight Time",
+		{"NST", -12600}:  "Newfoundland Standard Time",
+		{"NDT", -9000}:   "Newfoundland Daylight Time",
+	},
+	"zh": {
+		{"UTC", 0}:       "协调世界时",
+		{"GMT", 0}:       "格林尼治标准时间",
+		{"WET", 0}:       "西欧标准时间",
+		{"WEST", 3600}:   "西欧夏令时间",
+		{"BST", 3600}:    "英国夏令时间",
+		{"IST", 3600}:    "爱尔兰标准时间",
+		{"CET", 3600}:    "中欧标准时间",
+		{"CEST", 7200}:   "中欧夏令时间",
+		{"EET", 7200}:    "东欧标准时间",
+		{"EEST", 10800}:  "东欧夏令时间",
+		{"SAST", 7200}:   "南非标准时间",
+		{"IST", 7200}:    "以色列标准时间",
+		{"IDT", 10800}:   "以色列夏令时间",
+		{"MSK", 10800}:   "莫斯科标准时间",
+		{"PKT", 18000}:   "巴基斯坦标准时间",
+		{"IST", 19800}:   "印度时间",
+		{"WIB", 25200}:   "印度尼西亚西部时间",
+		{"CST", 28800}:   "中国标准时间",
+		{"HKT", 28800}:   "香港标准时间",
+		{"AWST", 28800}:  "澳大利亚西部标准时间",
+		{"JST", 32400}:   "日本标准时间",
+		{"KST", 32400}:   "韩国标准时间",
+		{"ACST", 34200}:  "澳大利亚中部标准时间",
+		{"ACDT", 37800}:  "澳大利亚中部夏令时间",
+		{"AEST", 36000}:  "澳大利亚东部标准时间",
+		{"AEDT", 39600}:  "澳大利亚东部夏令时间",
+		{"NZST", 43200}:  "新西兰标准时间",
+		{"NZDT", 46800}:  "新西兰夏令时间",
+		{"HST", -36000}:  "夏威夷-阿留申标准时间",
+		{"AKST", -32400}: "阿拉斯加标准时间",
+		{"AKDT", -28800}: "阿拉斯加夏令时间",
+		{"PST", -28800}:  "北美太平洋标准时间",
+		{"PDT", -25200}:  "北美太平洋夏令时间",
+		{"MST", -25200}:  "北美山区标准时间",
+		{"MDT", -21600}:  "北美山区夏令时间",
+		{"CST", -21600}:  "北美中部标准时间",
+		{"CDT", -18000}:  "北美中部夏令时间",
+		{"EST", -18000}:  "北美东部标准时间",
+		{"EDT", -14400}:  "北美东部夏令时间",
+		{"AST", -14400}:  "大西洋标准时间",
+		{"ADT", -10800}:  "大西洋夏令时间",
+		{"NST", -12600}:  "纽芬兰标准时间",
+		{"NDT", -9000}:   "纽芬兰夏令时间",
+	},
+}
@@ -0,0 +1,103 @@
+package i18n
+
+import (
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// CaseMapping is a case transformation which T applies to a translation when
+// passed among its arguments, e.g. T("SAVE", CaseUpper) for a button label.
+type CaseMapping int
+
+const (
+	// CaseNone leaves translations as is.
+	CaseNone CaseMapping = iota
+	// CaseUpper upper cases translations like ToUpper.
+	CaseUpper
+	// CaseLower lower cases translations like ToLower.
+	CaseLower
+	// CaseTitle title cases translations like ToTitle.
+	CaseTitle
+)
+
+// ToUpper upper cases s following the rules of the current locale, e.g.
+// "istanbul" becomes "İSTANBUL" in Turkish.
+func ToUpper(s string) string {
+	return currentLocalizer().ToUpper(s)
+}
+
+// ToLower lower cases s following the rules of the current locale, e.g.
+// "ISTANBUL" becomes "ıstanbul" in Turkish.
+func ToLower(s string) string {
+	return currentLocalizer().ToLower(s)
+}
+
+// ToTitle upper cases the first letter of each word of s and lower cases the
+// others, following the rules of the current locale, e.g. "ijssel" becomes
+// "IJssel" in Dutch.
+func ToTitle(s string) string {
+	return currentLocalizer().ToTitle(s)
+}
+
+// EqualFold reports whether a and b are equal ignoring case, following the
+// rules of the current locale, e.g. "I" and "ı" are but "I" and "i" aren't in
+// Turkish.
+func EqualFold(a, b string) bool {
+	return currentLocalizer().EqualFold(a, b)
+}
+
+// ToUpper upper cases s like the package level ToUpper, in the locale of l.
+func (l *Localizer) ToUpper(s string) string {
+	return mapCase(l.tag(), s, CaseUpper)
+}
+
+// ToLower lower cases s like the package level ToLower, in the locale of l.
+func (l *Localizer) ToLower(s string) string {
+	return mapCase(l.tag(), s, CaseLower)
+}
+
+// ToTitle title cases s like the package level ToTitle, in the locale of l.
+func (l *Localizer) ToTitle(s string) string {
+	return mapCase(l.tag(), s, CaseTitle)
+}
+
+// EqualFold compares a and b like the package level EqualFold, in the locale
+// of l.
+func (l *Localizer) EqualFold(a, b string) bool {
+	return equalFold(l.tag(), a, b)
+}
+
+func mapCase(tag language.Tag, s string, mapping CaseMapping) string {
+	switch mapping {
+	case CaseUpper:
+		return cases.Upper(tag).String(s)
+	case CaseLower:
+		return cases.Lower(tag).String(s)
+	case CaseTitle:
+		return cases.Title(tag).String(s)
+	default:
+		return s
+	}
+}
+
+// equalFold compares the case folding of a and b lower cased in the locale of
+// tag, so that language specific mappings such as Turkish dotless i apply.
+func equalFold(tag language.Tag, a, b string) bool {
+	lower, fold := cases.Lower(tag), cases.Fold()
+	return fold.String(lower.String(a)) == fold.String(lower.String(b))
+}
+
+// caseMappingArg removes the CaseMapping arguments from the arguments of T,
+// returning the last one.
+func caseMappingArg(args []interface{}) ([]interface{}, CaseMapping) {
+	mapping := CaseNone
+	var rest []interface{}
+	for _, arg := range args {
+		if m, isMapping := arg.(CaseMapping); isMapping {
+			mapping = m
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	return rest, mapping
+}
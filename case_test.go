@@ -0,0 +1,56 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestMapCase(t *testing.T) {
+	for _, c := range []struct {
+		locale   string
+		s        string
+		mapping  CaseMapping
+		expected string
+	}{
+		{"en-US", "istanbul", CaseUpper, "ISTANBUL"},
+		{"tr", "istanbul", CaseUpper, "İSTANBUL"},
+		{"az", "istanbul", CaseUpper, "İSTANBUL"},
+		{"en-US", "DIYARBAKIR", CaseLower, "diyarbakir"},
+		{"tr", "DIYARBAKIR", CaseLower, "dıyarbakır"},
+		{"lt", "Ì", CaseLower, "i̇̀"},
+		{"en-US", "hello wORLD", CaseTitle, "Hello World"},
+		{"nl", "ijssel", CaseTitle, "IJssel"},
+		{"de", "straße", CaseUpper, "STRASSE"},
+		{"en-US", "As Is", CaseNone, "As Is"},
+	} {
+		assert.Equal(t, c.expected, mapCase(language.Make(c.locale), c.s, c.mapping), "%v in %v", c.s, c.locale)
+	}
+}
+
+func TestEqualFold(t *testing.T) {
+	en, tr := language.English, language.Turkish
+	assert.True(t, equalFold(en, "I", "i"))
+	assert.False(t, equalFold(tr, "I", "i"))
+	assert.True(t, equalFold(tr, "I", "ı"))
+	assert.True(t, equalFold(tr, "İSTANBUL", "istanbul"))
+	assert.True(t, equalFold(en, "STRASSE", "straße"))
+	assert.False(t, equalFold(en, "a", "b"))
+}
+
+func TestCaseMappingArg(t *testing.T) {
+	l := &Localizer{locale: "tr", messages: map[string]string{
+		"SAVE":  "kaydet",
+		"HELLO": "merhaba %s",
+		"FILES": "{count, number} dosya",
+	}}
+	assert.Equal(t, "KAYDET", l.T("SAVE", CaseUpper))
+	assert.Equal(t, "MERHABA İREM", l.T("HELLO", "irem", CaseUpper))
+	assert.Equal(t, "Merhaba İrem", l.T("HELLO", CaseTitle, "irem"))
+	assert.Equal(t, "3 DOSYA", l.T("FILES", CaseUpper, Params{"count": 3}))
+	assert.Equal(t, "İSTANBUL", l.ToUpper("istanbul"))
+	assert.Equal(t, "ıı", l.ToLower("II"))
+	assert.Equal(t, "İstanbul", l.ToTitle("istanbul"))
+	assert.True(t, l.EqualFold("ISPARTA", "ısparta"))
+}
@@ -0,0 +1,245 @@
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// contextSeparator separates the context of a message from its key, as in
+// the JSON files produced from gettext catalogs, e.g. "menu\u0004OPEN".
+const contextSeparator = "\x04"
+
+// ContextKey returns the key under which the message key is stored in the
+// given context, e.g. for "OPEN" as a verb in a menu rather than as an
+// adjective. Translation files write it as "menu\u0004OPEN". Messages without
+// a context, such as ContextKey("", "OPEN"), are stored under the key itself.
+func ContextKey(context, key string) string {
+	if context == "" {
+		return key
+	}
+	return context + contextSeparator + key
+}
+
+// SplitContextKey splits a key returned by ContextKey into its context and
+// message key. The context of keys without one is empty.
+func SplitContextKey(key string) (context, msgKey string) {
+	if i := strings.Index(key, contextSeparator); i >= 0 {
+		return key[:i], key[i+len(contextSeparator):]
+	}
+	return "", key
+}
+
+// describeKey names key in error messages, e.g. "OPEN (context menu)" or
+// "WELCOME (formal)" for the variant of a key.
+func describeKey(key string) string {
+	key, r := splitRegisterKey(key)
+	context, msgKey := SplitContextKey(key)
+	var qualifiers []string
+	if context != "" {
+		qualifiers = append(qualifiers, "context "+context)
+	}
+	if r != RegisterNeutral {
+		qualifiers = append(qualifiers, r.String())
+	}
+	if len(qualifiers) == 0 {
+		return msgKey
+	}
+	return fmt.Sprintf("%s (%s)", msgKey, strings.Join(qualifiers, ", "))
+}
+
+// MessageInfo describes a message to translators. Translation files supply
+// it by writing the message as an object instead of a string, e.g.
+//
+//   {"SAVE": {"value": "Save", "description": "Button saving the form", "maxLength": 12}}
+type MessageInfo struct {
+	// Description tells translators what the message means and where it's
+	// shown
+	Description string `json:"description,omitempty"`
+	// MaxLength is the maximum number of characters of the message and its
+	// translations as written in translation files, e.g. to fit a button, or
+	// 0 for no limit. Locales with longer translations fail to load like
+	// locales with malformed files.
+	MaxLength int `json:"maxLength,omitempty"`
+	// Screenshot is the URL or path of a screenshot showing the message
+	Screenshot string `json:"screenshot,omitempty"`
+}
+
+// catalogEntry is a message of a translation file, written either as a string
+// or as an object with its variants and MessageInfo.
+type catalogEntry struct {
+	Value    *string `json:"value"`
+	Formal   *string `json:"formal,omitempty"`
+	Informal *string `json:"informal,omitempty"`
+	MessageInfo
+}
+
+func (e *catalogEntry) UnmarshalJSON(b []byte) error {
+	if len(b) > 0 && b[0] == '"' {
+		return json.Unmarshal(b, &e.Value)
+	}
+	// without the UnmarshalJSON method
+	type entry catalogEntry
+	return json.Unmarshal(b, (*entry)(e))
+}
+
+// checkLength checks that s is no longer than the MaxLength of info.
+func checkLength(s string, info MessageInfo) error {
+	if n := utf8.RuneCountInString(s); info.MaxLength > 0 && n > info.MaxLength {
+		return fmt.Errorf("%d characters, longer than the maximum of %d", n, info.MaxLength)
+	}
+	return nil
+}
+
+// Catalog holds the messages of a single translation file, keyed like the
+// file, for conversion between the JSON files read by SetLocale and formats
+// used by translators, such as PO and XLIFF. Messages in a context are keyed
+// by ContextKey.
+type Catalog struct {
+	Locale   string
+	Messages map[string]string
+	// Info holds the MessageInfo of the keys which have one, and may be nil
+	Info map[string]MessageInfo
+	// Variants holds the formal and informal variants of the keys which have
+	// some, and may be nil. They're only read from and written to JSON.
+	Variants map[string]map[Register]string
+}
+
+// merged returns the messages of c along with its variants, keyed by
+// registerKey, as they're merged into the translations of a Localizer.
+func (c *Catalog) merged() map[string]string {
+	if len(c.Variants) == 0 {
+		return c.Messages
+	}
+	m := make(map[string]string, len(c.Messages))
+	for k, v := range c.Messages {
+		m[k] = v
+	}
+	for k, variants := range c.Variants {
+		for r, v := range variants {
+			m[registerKey(k, r)] = v
+		}
+	}
+	return m
+}
+
+// LoadCatalog reads the translation file of the given locale from the message
+// source, without the translations of its fallbacks. With several layers, it
+// merges the files of the locale in every layer, and fails with a *LoadError
+// if any of them exists but can't be loaded. It fails with an error wrapping
+// os.ErrNotExist if there's no file for the locale.
+func LoadCatalog(locale string) (*Catalog, error) {
+	locale = strings.Replace(locale, "_", "-", -1)
+	c, _, _, errs := loadLocale(locale, false)
+	if len(errs) > 0 {
+		return nil, &LoadError{Locale: locale, Files: errs}
+	}
+	if c == nil {
+		return nil, fmt.Errorf("No translations for locale %s: %w", locale, os.ErrNotExist)
+	}
+	return c, nil
+}
+
+// ReadJSON reads the catalog of the given locale from r in the format of the
+// translation files.
+func ReadJSON(r io.Reader, locale string) (*Catalog, error) {
+	buf, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	c, fileErr := decodeCatalog(locale+".json", locale, buf)
+	if fileErr != nil {
+		return nil, fileErr
+	}
+	if fileErr := validateMessages(locale+".json", c.merged(), c.Info); fileErr != nil {
+		return nil, fileErr
+	}
+	return c, nil
+}
+
+// decodeCatalog decodes the translation file fileName of locale and checks
+// the markup of its messages. Their length is left to the caller, as loading
+// a locale checks it against the MaxLength of every file.
+func decodeCatalog(fileName string, locale string, buf []byte) (*Catalog, *FileError) {
+	var entries map[string]catalogEntry
+	if err := json.Unmarshal(buf, &entries); err != nil {
+		return nil, newDecodeError(fileName, buf, err)
+	}
+	c := &Catalog{Locale: locale, Messages: make(map[string]string, len(entries))}
+	keys := make([]string, 0, len(entries))
+	for k := range entries {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		e := entries[k]
+		if e.Value == nil {
+			return nil, &FileError{File: fileName, Offset: -1, Err: fmt.Errorf("%s: Missing value of message", describeKey(k))}
+		}
+		c.Messages[k] = *e.Value
+		for r, v := range map[Register]*string{RegisterFormal: e.Formal, RegisterInformal: e.Informal} {
+			if v == nil {
+				continue
+			}
+			if c.Variants == nil {
+				c.Variants = make(map[string]map[Register]string)
+			}
+			if c.Variants[k] == nil {
+				c.Variants[k] = make(map[Register]string)
+			}
+			c.Variants[k][r] = *v
+		}
+		if e.MessageInfo != (MessageInfo{}) {
+			if c.Info == nil {
+				c.Info = make(map[string]MessageInfo)
+			}
+			c.Info[k] = e.MessageInfo
+		}
+	}
+	if err := validateMessages(fileName, c.merged(), nil); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// WriteJSON writes c to w in the format of the translation files, sorted by
+// key. Messages with variants or a MessageInfo are written as objects.
+func WriteJSON(w io.Writer, c *Catalog) error {
+	entries := make(map[string]interface{}, len(c.Messages))
+	for k, v := range c.Messages {
+		entries[k] = v
+		info, found := c.Info[k]
+		variants := c.Variants[k]
+		if !found && len(variants) == 0 {
+			continue
+		}
+		e := catalogEntry{Value: new(string), MessageInfo: info}
+		*e.Value = v
+		if formal, found := variants[RegisterFormal]; found {
+			e.Formal = &formal
+		}
+		if informal, found := variants[RegisterInformal]; found {
+			e.Informal = &informal
+		}
+		entries[k] = e
+	}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(entries)
+}
+
+// sortedKeys returns the keys of m in order.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,147 @@
+package i18n
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var contextMessages = map[string]string{
+	"en.json": `{"OPEN": "Open", "menu\u0004OPEN": "Open", "status\u0004OPEN": "Open {0}"}`,
+	"de.json": `{"OPEN": "Offen", "menu\u0004OPEN": "Öffnen"}`,
+}
+
+func setContextMessages() {
+	SetMessagesFunc(func(path string) ([]byte, error) {
+		if s, found := contextMessages[path]; found {
+			return []byte(s), nil
+		}
+		return nil, os.ErrNotExist
+	})
+}
+
+func TestContext(t *testing.T) {
+	defer restoreState()()
+	setContextMessages()
+	if !assert.NoError(t, setLocale("de")) {
+		return
+	}
+	assert.Equal(t, "Offen", T("OPEN"))
+	assert.Equal(t, "Öffnen", TC("menu", "OPEN"))
+	assert.Equal(t, "Offen", TC("", "OPEN"))
+	assert.Equal(t, "Open 3", TC("status", "OPEN", 3), "should fall back within the context")
+	assert.Equal(t, "[OPEN]", TC("toolbar", "OPEN"), "shouldn't fall back to the key without context")
+
+	var missing string
+	SetMissingHandler(func(key string, locale string, fallbacks []string) string {
+		missing = key
+		return MissingKey(key, locale, fallbacks)
+	})
+	defer SetMissingHandler(nil)
+	assert.Equal(t, "OPEN", TC("toolbar", "OPEN"))
+	assert.Equal(t, "toolbar\x04OPEN", missing)
+
+	assert.Equal(t, "menu\x04OPEN", ContextKey("menu", "OPEN"))
+	assert.Equal(t, "OPEN", ContextKey("", "OPEN"))
+	context, key := SplitContextKey("menu\x04OPEN")
+	assert.Equal(t, []string{"menu", "OPEN"}, []string{context, key})
+	context, key = SplitContextKey("OPEN")
+	assert.Equal(t, []string{"", "OPEN"}, []string{context, key})
+}
+
+func TestCatalogJSON(t *testing.T) {
+	defer restoreState()()
+	setContextMessages()
+	c, err := LoadCatalog("de")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, &Catalog{Locale: "de", Messages: map[string]string{"OPEN": "Offen", "menu\x04OPEN": "Öffnen"}}, c)
+	_, err = LoadCatalog("fr")
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+
+	var buf bytes.Buffer
+	if assert.NoError(t, WriteJSON(&buf, c)) {
+		assert.Equal(t, "{\n  \"OPEN\": \"Offen\",\n  \"menu\\u0004OPEN\": \"Öffnen\"\n}\n", buf.String())
+	}
+	read, err := ReadJSON(&buf, "de")
+	if assert.NoError(t, err) {
+		assert.Equal(t, c, read)
+	}
+	_, err = ReadJSON(strings.NewReader(`{"menu\u0004OPEN": "<b><i>Öffnen</b></i>"}`), "de")
+	assert.EqualError(t, err, "de.json: OPEN (context menu): tag at offset 13: </b> crosses <i>")
+	_, err = ReadJSON(strings.NewReader(`{"SAVE": {"value": "Speichern", "maxLength": 8}}`), "de")
+	assert.EqualError(t, err, "de.json: SAVE: 9 characters, longer than the maximum of 8")
+}
+
+func TestMessageInfo(t *testing.T) {
+	defer restoreState()()
+	files := map[string]string{
+		"en.json": `{"SAVE": {"value": "Save", "description": "Button saving the form", "maxLength": 8, "screenshot": "form.png"}, "OPEN": "Open"}`,
+		"de.json": `{"SAVE": "Speichern"}`,
+		"fr.json": `{"SAVE": "Enregistrer sous", "OPEN": {"value": "Ouvrir", "description": "Ouvre un fichier"}}`,
+		"es.json": `{"SAVE": {"description": "Guarda"}}`,
+	}
+	SetMessagesFunc(func(path string) ([]byte, error) {
+		if s, found := files[path]; found {
+			return []byte(s), nil
+		}
+		return nil, os.ErrNotExist
+	})
+	if !assert.NoError(t, setLocale("en")) {
+		return
+	}
+	assert.Equal(t, "Save", T("SAVE"))
+	info, found := Info("SAVE")
+	assert.True(t, found)
+	assert.Equal(t, MessageInfo{Description: "Button saving the form", MaxLength: 8, Screenshot: "form.png"}, info)
+	_, found = Info("OPEN")
+	assert.False(t, found)
+
+	de, err := NewLocalizer("de")
+	var loadErr *LoadError
+	if assert.True(t, errors.As(err, &loadErr), "should enforce the maximum length of en") && assert.Len(t, loadErr.Files, 1) {
+		assert.EqualError(t, loadErr.Files[0], "de.json: SAVE: 9 characters, longer than the maximum of 8")
+	}
+	if assert.NotNil(t, de, "should load the locale despite the length") {
+		assert.Equal(t, "Speichern", de.T("SAVE"))
+	}
+	de, err = LoadLocalizer("de", LoadOptions{StrictLength: true})
+	assert.Nil(t, de)
+	assert.EqualError(t, err, "Error loading locale de: de.json: SAVE: 9 characters, longer than the maximum of 8")
+	fr, err := LoadLocalizer("fr", LoadOptions{AllowPartial: true})
+	assert.EqualError(t, err, "Error loading locale fr: fr.json: SAVE: 16 characters, longer than the maximum of 8")
+	if assert.NotNil(t, fr) {
+		assert.Equal(t, "Ouvrir", fr.T("OPEN"))
+		info, _ = fr.Info("OPEN")
+		assert.Equal(t, "Ouvre un fichier", info.Description, "should take the info of the file of highest precedence")
+	}
+	_, err = NewLocalizer("es")
+	assert.EqualError(t, err, "Error loading locale es: es.json: SAVE: Missing value of message")
+
+	c, err := LoadCatalog("en")
+	if !assert.NoError(t, err) {
+		return
+	}
+	var buf bytes.Buffer
+	if assert.NoError(t, WriteJSON(&buf, c)) {
+		assert.Equal(t, `{
+  "OPEN": "Open",
+  "SAVE": {
+    "value": "Save",
+    "description": "Button saving the form",
+    "maxLength": 8,
+    "screenshot": "form.png"
+  }
+}
+`, buf.String())
+	}
+	read, err := ReadJSON(&buf, "en")
+	if assert.NoError(t, err) {
+		assert.Equal(t, c, read)
+	}
+}
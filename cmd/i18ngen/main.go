@@ -0,0 +1,462 @@
+// Command i18ngen reads the default-locale catalog and generates a Go package
+// with one accessor function per translation key, so that a typo in a key is
+// a compile error instead of a "[KEY]" string at runtime.
+//
+// It is meant to be run through go generate, for example:
+//
+//   //go:generate i18ngen -dir locale -pkg msg -o msg/msg.go
+//
+// For a key "HELLO" with the message "Hello %s!" it generates
+//
+//   func Hello(arg1 string) string { return i18n.T("HELLO", arg1) }
+//
+// Keys in a context, such as "menu\u0004OPEN", are translated with i18n.TC
+// by a function named after both, MenuOpen.
+//
+// Parameter types are inferred from the placeholders of the message, or from
+// its printf verbs if it has no placeholders. Named placeholders become
+// parameters of the same name, passed to T in i18n.Params.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/getlantern/i18n"
+)
+
+var (
+	dir        = flag.String("dir", "locale", "directory containing the translation json files")
+	locale     = flag.String("locale", "en-US", "default locale whose catalog defines the keys")
+	pkg        = flag.String("pkg", "msg", "name of the generated package")
+	out        = flag.String("o", "", "output file, defaults to stdout")
+	importPath = flag.String("import", "github.com/getlantern/i18n", "import path of the i18n package")
+)
+
+func main() {
+	flag.Parse()
+	src, err := generate(*dir, *locale, *pkg, *importPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "i18ngen: %v\n", err)
+		os.Exit(1)
+	}
+	if *out == "" {
+		os.Stdout.Write(src)
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(*out), 0755); err != nil {
+		fmt.Fprintf(os.Stderr, "i18ngen: %v\n", err)
+		os.Exit(1)
+	}
+	if err := ioutil.WriteFile(*out, src, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "i18ngen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// generate builds the source of the accessor package from the catalog of the
+// given locale, merged over the catalog of its language the same way
+// i18n.SetLocale merges them.
+func generate(dir string, locale string, pkg string, importPath string) ([]byte, error) {
+	locale = strings.Replace(locale, "_", "-", -1)
+	lang := strings.Split(locale, "-")[0]
+	messages := make(map[string]string)
+	info := make(map[string]i18n.MessageInfo)
+	found := false
+	for _, l := range []string{lang, locale} {
+		c, err := loadCatalog(dir, l)
+		if err != nil {
+			return nil, err
+		}
+		if c == nil {
+			continue
+		}
+		found = true
+		for k, v := range c.Messages {
+			messages[k] = v
+		}
+		for k, v := range c.Info {
+			info[k] = v
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("no catalog found for %s in %s", locale, dir)
+	}
+
+	keys := make([]string, 0, len(messages))
+	for k := range messages {
+		// glossary terms aren't messages
+		if _, ok := i18n.SplitGlossaryKey(k); !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var body bytes.Buffer
+	i18nPkg := packageName(importPath)
+	imports := []string{importPath}
+	byName := make(map[string]string, len(keys))
+	for _, key := range keys {
+		name := identifier(key)
+		if other, dup := byName[name]; dup {
+			return nil, fmt.Errorf("keys %s and %s both map to function %s", other, key, name)
+		}
+		byName[name] = key
+		params, err := messageParams(messages[key], i18nPkg)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %v", key, err)
+		}
+		var decl, args, named []string
+		for _, p := range params {
+			if strings.HasPrefix(p.typ, "time.") && imports[0] != "time" {
+				imports = append([]string{"time"}, imports...)
+			}
+			decl = append(decl, p.ident+" "+p.typ)
+			if p.name == "" {
+				args = append(args, p.ident)
+			} else {
+				named = append(named, fmt.Sprintf("%q: %s", p.name, p.ident))
+			}
+		}
+		if len(named) > 0 {
+			args = append(args, fmt.Sprintf("%s.Params{%s}", i18nPkg, strings.Join(named, ", ")))
+		}
+		context, msgKey := i18n.SplitContextKey(key)
+		fn, call, desc := "T", fmt.Sprintf("%q", msgKey), msgKey
+		if context != "" {
+			fn, call, desc = "TC", fmt.Sprintf("%q, %q", context, msgKey), msgKey+" in context "+context
+		}
+		if len(args) > 0 {
+			call += ", " + strings.Join(args, ", ")
+		}
+		fmt.Fprintf(&body, "\n// %s returns the translation of %s (%q).\n", name, desc, messages[key])
+		if description := info[key].Description; description != "" {
+			fmt.Fprintf(&body, "//\n// %s\n", strings.Replace(description, "\n", "\n// ", -1))
+		}
+		fmt.Fprintf(&body, "func %s(%s) string {\n\treturn %s.%s(%s)\n}\n", name, strings.Join(decl, ", "), i18nPkg, fn, call)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by i18ngen from %s; DO NOT EDIT.\n\n", path.Join(dir, locale+".json"))
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+	fmt.Fprintf(&buf, "import (\n")
+	for _, imp := range imports {
+		if imp == importPath && i18nPkg != path.Base(imp) {
+			fmt.Fprintf(&buf, "\t%s %q\n", i18nPkg, imp)
+			continue
+		}
+		fmt.Fprintf(&buf, "\t%q\n", imp)
+	}
+	fmt.Fprintf(&buf, ")\n")
+	body.WriteTo(&buf)
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %v", err)
+	}
+	return src, nil
+}
+
+// packageName returns the name under which the generated code refers to the
+// package of importPath, which is its last element without a major version
+// suffix such as /v2 or .v2, made a valid identifier.
+func packageName(importPath string) string {
+	elems := strings.Split(importPath, "/")
+	name := elems[len(elems)-1]
+	if len(elems) > 1 && isMajorVersion(name[1:]) && name[0] == 'v' {
+		name = elems[len(elems)-2]
+	}
+	if i := strings.LastIndex(name, ".v"); i > 0 && isMajorVersion(name[i+2:]) {
+		name = name[:i]
+	}
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+	if name == "" || !unicode.IsLetter([]rune(name)[0]) || token.IsKeyword(name) {
+		name = "i18n"
+	}
+	return name
+}
+
+func isMajorVersion(s string) bool {
+	n, err := strconv.Atoi(s)
+	return err == nil && n > 0
+}
+
+// loadCatalog reads the catalog of the given locale from dir, returning nil
+// without error if the file does not exist.
+func loadCatalog(dir string, locale string) (*i18n.Catalog, error) {
+	fileName := filepath.Join(dir, locale+".json")
+	f, err := os.Open(fileName)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	c, err := i18n.ReadJSON(f, locale)
+	if err != nil {
+		return nil, fmt.Errorf("Error decode json file %s: %s", fileName, err)
+	}
+	return c, nil
+}
+
+// identifier converts a key such as ONLY_IN_EN or menu.open into an exported
+// Go identifier such as OnlyInEn or MenuOpen. The context of a key is part of
+// the identifier.
+func identifier(key string) string {
+	parts := strings.FieldsFunc(key, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	for _, part := range parts {
+		if strings.ToUpper(part) == part {
+			part = strings.ToLower(part)
+		}
+		runes := []rune(part)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	name := b.String()
+	if name == "" || !unicode.IsLetter([]rune(name)[0]) {
+		name = "Msg" + name
+	}
+	return name
+}
+
+// param is a parameter of a generated function.
+type param struct {
+	// ident is the name of the Go parameter
+	ident string
+	typ   string
+	// name is the name of the placeholder if the argument is passed in
+	// i18n.Params, and empty if it's passed positionally.
+	name string
+}
+
+// messageParams returns the parameters of the function generated for msg,
+// inferred from its placeholders or, if it has none, its printf verbs. i18nPkg
+// is the name of the i18n package.
+func messageParams(msg string, i18nPkg string) ([]param, error) {
+	placeholders, err := i18n.Placeholders(msg)
+	if err != nil {
+		return nil, err
+	}
+	var params []param
+	if placeholders == nil {
+		for i, typ := range inferParams(msg) {
+			params = append(params, param{ident: fmt.Sprintf("arg%d", i+1), typ: typ})
+		}
+		return params, nil
+	}
+	var positional []string
+	var named []param
+	index := make(map[string]int)
+	for _, p := range placeholders {
+		typ := placeholderType(p, i18nPkg)
+		if n, err := strconv.Atoi(p.Name); err == nil && n >= 0 {
+			for len(positional) <= n {
+				positional = append(positional, "")
+			}
+			if positional[n] != "" && positional[n] != typ {
+				typ = "interface{}"
+			}
+			positional[n] = typ
+			continue
+		}
+		if i, found := index[p.Name]; found {
+			if named[i].typ != typ {
+				named[i].typ = "interface{}"
+			}
+			continue
+		}
+		index[p.Name] = len(named)
+		named = append(named, param{typ: typ, name: p.Name})
+	}
+	// the package names used by the generated code aren't available as
+	// parameter names
+	used := map[string]bool{i18nPkg: true, "time": true}
+	for i, typ := range positional {
+		if typ == "" {
+			typ = "interface{}"
+		}
+		ident := fmt.Sprintf("arg%d", i+1)
+		used[ident] = true
+		params = append(params, param{ident: ident, typ: typ})
+	}
+	for i := range named {
+		named[i].ident = uniqueIdentifier(paramIdentifier(named[i].name), used)
+	}
+	return append(params, named...), nil
+}
+
+// uniqueIdentifier returns ident, suffixed with 2, 3 and so on if it's
+// already used, and marks the result as used.
+func uniqueIdentifier(ident string, used map[string]bool) string {
+	s := ident
+	for n := 2; used[s]; n++ {
+		s = fmt.Sprintf("%s%d", ident, n)
+	}
+	used[s] = true
+	return s
+}
+
+// placeholderType returns the Go type of the argument of a placeholder, with
+// i18nPkg the name of the i18n package.
+func placeholderType(p i18n.Placeholder, i18nPkg string) string {
+	switch p.Type {
+	case "number":
+		if p.Style == "integer" {
+			return "int"
+		}
+		return "float64"
+	case "currency":
+		for _, field := range strings.Fields(p.Style) {
+			if len(field) == 3 && strings.ToUpper(field) == field {
+				return i18nPkg + ".Decimal"
+			}
+		}
+		return i18nPkg + ".Money"
+	case "date", "time":
+		return "time.Time"
+	case "list":
+		return "[]string"
+	case "unit", "plural":
+		return "float64"
+	case "select":
+		return "string"
+	case "selectordinal", "ordinal":
+		return "int"
+	case "bytes":
+		if strings.Contains(p.Style, "rate") {
+			return "float64"
+		}
+		return "int64"
+	default:
+		return "interface{}"
+	}
+}
+
+// paramIdentifier converts a placeholder name such as user_name into a Go
+// parameter name such as userName.
+func paramIdentifier(name string) string {
+	ident := []rune(identifier(name))
+	ident[0] = unicode.ToLower(ident[0])
+	s := string(ident)
+	if token.IsKeyword(s) {
+		s += "Arg"
+	}
+	return s
+}
+
+// inferParams returns the Go type of each argument consumed by the printf
+// verbs in msg. An argument used by verbs of different types, or by a verb
+// which accepts any value, is typed interface{}.
+func inferParams(msg string) []string {
+	var params []string
+	use := func(i int, typ string) {
+		for len(params) <= i {
+			params = append(params, "")
+		}
+		if params[i] != "" && params[i] != typ {
+			typ = "interface{}"
+		}
+		params[i] = typ
+	}
+	argNum := 0
+	for i := 0; i < len(msg); i++ {
+		if msg[i] != '%' {
+			continue
+		}
+		i++
+		// flags
+		for i < len(msg) && strings.IndexByte("+-# 0", msg[i]) >= 0 {
+			i++
+		}
+		argNum = parseArgIndex(msg, &i, argNum)
+		// width
+		if i < len(msg) && msg[i] == '*' {
+			use(argNum, "int")
+			argNum++
+			i++
+		}
+		for i < len(msg) && msg[i] >= '0' && msg[i] <= '9' {
+			i++
+		}
+		// precision
+		if i < len(msg) && msg[i] == '.' {
+			i++
+			argNum = parseArgIndex(msg, &i, argNum)
+			if i < len(msg) && msg[i] == '*' {
+				use(argNum, "int")
+				argNum++
+				i++
+			}
+			for i < len(msg) && msg[i] >= '0' && msg[i] <= '9' {
+				i++
+			}
+		}
+		argNum = parseArgIndex(msg, &i, argNum)
+		if i >= len(msg) || msg[i] == '%' {
+			continue
+		}
+		use(argNum, verbType(msg[i]))
+		argNum++
+	}
+	for i, typ := range params {
+		if typ == "" {
+			// skipped by explicit indexes, still has to be passed
+			params[i] = "interface{}"
+		}
+	}
+	return params
+}
+
+// parseArgIndex parses an explicit argument index such as [2] at msg[*i],
+// returning the zero-based index of the next argument.
+func parseArgIndex(msg string, i *int, argNum int) int {
+	if *i >= len(msg) || msg[*i] != '[' {
+		return argNum
+	}
+	end := strings.IndexByte(msg[*i:], ']')
+	if end < 0 {
+		return argNum
+	}
+	var n int
+	if _, err := fmt.Sscanf(msg[*i+1:*i+end], "%d", &n); err != nil || n < 1 {
+		return argNum
+	}
+	*i += end + 1
+	return n - 1
+}
+
+func verbType(verb byte) string {
+	switch verb {
+	case 's', 'q':
+		return "string"
+	case 'd', 'b', 'o', 'O':
+		return "int"
+	case 'c', 'U':
+		return "rune"
+	case 'e', 'E', 'f', 'F', 'g', 'G':
+		return "float64"
+	case 't':
+		return "bool"
+	default:
+		return "interface{}"
+	}
+}
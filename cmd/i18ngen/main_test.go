@@ -0,0 +1,159 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdentifier(t *testing.T) {
+	assert.Equal(t, "Hello", identifier("HELLO"))
+	assert.Equal(t, "OnlyInEnUs", identifier("ONLY_IN_EN_US"))
+	assert.Equal(t, "MenuOpenFile", identifier("menu.openFile"))
+	assert.Equal(t, "Msg404NotFound", identifier("404_NOT_FOUND"))
+}
+
+func TestInferParams(t *testing.T) {
+	assert.Empty(t, inferParams("I speak Generic English!"))
+	assert.Empty(t, inferParams("100%% done"))
+	assert.Equal(t, []string{"string"}, inferParams("Hello %s!"))
+	assert.Equal(t, []string{"int", "float64", "bool"}, inferParams("%d %.2f %t"))
+	assert.Equal(t, []string{"int", "string"}, inferParams("%-*s"))
+	assert.Equal(t, []string{"string", "int"}, inferParams("%[2]d %[1]s"))
+	assert.Equal(t, []string{"interface{}"}, inferParams("%[1]s %[1]d"))
+	assert.Equal(t, []string{"interface{}", "string"}, inferParams("%[2]s"))
+}
+
+func TestMessageParams(t *testing.T) {
+	params, err := messageParams("{0} has {count, number, integer} files, {user_name} {count, number, integer} {1, number}", "i18n")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []param{
+			{ident: "arg1", typ: "interface{}"},
+			{ident: "arg2", typ: "float64"},
+			{ident: "count", typ: "int", name: "count"},
+			{ident: "userName", typ: "interface{}", name: "user_name"},
+		}, params)
+	}
+	params, err = messageParams("Hello %s!", "i18n")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []param{{ident: "arg1", typ: "string"}}, params)
+	}
+	params, err = messageParams("{type}", "i18n")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "typeArg", params[0].ident, "should not use keywords as parameter names")
+	}
+	params, err = messageParams("{price, currency} or {0, currency, EUR narrow}", "i18n")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []param{
+			{ident: "arg1", typ: "i18n.Decimal"},
+			{ident: "price", typ: "i18n.Money", name: "price"},
+		}, params)
+	}
+	params, err = messageParams("Sent {when, date, ::yMMMd}", "i18n")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []param{{ident: "when", typ: "time.Time", name: "when"}}, params)
+	}
+	params, err = messageParams("Shared with {names, list}", "i18n")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []param{{ident: "names", typ: "[]string", name: "names"}}, params)
+	}
+	params, err = messageParams("{0, bytes} at {1, bytes, rate} over {2, unit, kilometer}", "i18n")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []param{{ident: "arg1", typ: "int64"}, {ident: "arg2", typ: "float64"}, {ident: "arg3", typ: "float64"}}, params)
+	}
+	params, err = messageParams("{count, plural, one {# file} other {# files}}", "i18n")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []param{{ident: "count", typ: "float64", name: "count"}}, params)
+	}
+	params, err = messageParams("{gender, select, female {She} other {They}} shared {count, plural, one {# file} other {# files}}", "i18n")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []param{{ident: "gender", typ: "string", name: "gender"}, {ident: "count", typ: "float64", name: "count"}}, params)
+	}
+	params, err = messageParams("You are {0, ordinal} in the queue, {1, selectordinal, one {#st} other {#th}} overall", "i18n")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []param{{ident: "arg1", typ: "int"}, {ident: "arg2", typ: "int"}}, params)
+	}
+	params, err = messageParams("{user_name} {userName} {i18n} {time} {arg1} {0}", "i18n")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []param{
+			{ident: "arg1", typ: "interface{}"},
+			{ident: "userName", typ: "interface{}", name: "user_name"},
+			{ident: "userName2", typ: "interface{}", name: "userName"},
+			{ident: "i18n2", typ: "interface{}", name: "i18n"},
+			{ident: "time2", typ: "interface{}", name: "time"},
+			{ident: "arg12", typ: "interface{}", name: "arg1"},
+		}, params, "should not reuse identifiers or package names")
+	}
+	_, err = messageParams("{broken", "i18n")
+	assert.Error(t, err)
+}
+
+func TestGenerate(t *testing.T) {
+	src, err := generate("../../locale", "en_US", "msg", "github.com/getlantern/i18n")
+	if !assert.NoError(t, err) {
+		return
+	}
+	s := string(src)
+	assert.True(t, strings.HasPrefix(s, "// Code generated by i18ngen"))
+	assert.Contains(t, s, "func Hello(arg1 string) string {\n\treturn i18n.T(\"HELLO\", arg1)\n}")
+	assert.Contains(t, s, "func OnlyInEnUs() string {\n\treturn i18n.T(\"ONLY_IN_EN_US\")\n}")
+	assert.Contains(t, s, "func Blank() string")
+	_, err = parser.ParseFile(token.NewFileSet(), "msg.go", src, 0)
+	assert.NoError(t, err, "generated code should parse")
+
+	_, err = generate("not-existed-dir", "en_US", "msg", "github.com/getlantern/i18n")
+	assert.Error(t, err, "should error if no catalog found")
+}
+
+func TestPackageName(t *testing.T) {
+	assert.Equal(t, "i18n", packageName("github.com/getlantern/i18n"))
+	assert.Equal(t, "i18n", packageName("example.com/i18n/v2"))
+	assert.Equal(t, "i18n", packageName("gopkg.in/i18n.v1"))
+	assert.Equal(t, "go_i18n", packageName("example.com/go-i18n"))
+}
+
+func TestGenerateImport(t *testing.T) {
+	dir := t.TempDir()
+	catalog := `{"SENT": "{user_name} sent {userName} {when, date} via {i18n}"}`
+	if !assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "en.json"), []byte(catalog), 0644)) {
+		return
+	}
+	src, err := generate(dir, "en", "msg", "example.com/fork/i18n/v2")
+	if !assert.NoError(t, err) {
+		return
+	}
+	s := string(src)
+	assert.Contains(t, s, "\"example.com/fork/i18n/v2\"")
+	assert.NotContains(t, s, "github.com/getlantern/i18n")
+	assert.Contains(t, s, "func Sent(userName interface{}, userName2 interface{}, when time.Time, i18n2 interface{}) string {\n\treturn i18n.T(\"SENT\", i18n.Params{\"user_name\": userName, \"userName\": userName2, \"when\": when, \"i18n\": i18n2})\n}")
+	_, err = parser.ParseFile(token.NewFileSet(), "msg.go", src, 0)
+	assert.NoError(t, err, "generated code should parse")
+
+	src, err = generate(dir, "en", "msg", "example.com/go-i18n")
+	if assert.NoError(t, err) {
+		assert.Contains(t, string(src), "go_i18n \"example.com/go-i18n\"")
+	}
+}
+
+func TestGenerateContext(t *testing.T) {
+	dir := t.TempDir()
+	catalog := `{"{{APP_NAME}}": "Lantern", "OPEN": "Open", "menu\u0004OPEN": "Open {0}", "status\u0004OPEN": {"value": "Open", "description": "Status of a file\nnext to its name"}}`
+	if !assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "en.json"), []byte(catalog), 0644)) {
+		return
+	}
+	src, err := generate(dir, "en_US", "msg", "github.com/getlantern/i18n")
+	if !assert.NoError(t, err) {
+		return
+	}
+	s := string(src)
+	assert.Contains(t, s, "func Open() string {\n\treturn i18n.T(\"OPEN\")\n}")
+	assert.NotContains(t, s, "AppName", "should skip glossary terms")
+	assert.Contains(t, s, "// MenuOpen returns the translation of OPEN in context menu (\"Open {0}\").")
+	assert.Contains(t, s, "func MenuOpen(arg1 interface{}) string {\n\treturn i18n.TC(\"menu\", \"OPEN\", arg1)\n}")
+	assert.Contains(t, s, "// StatusOpen returns the translation of OPEN in context status (\"Open\").\n//\n// Status of a file\n// next to its name\nfunc StatusOpen() string {\n\treturn i18n.TC(\"status\", \"OPEN\")\n}")
+}
@@ -0,0 +1,119 @@
+package i18n
+
+import (
+	"bytes"
+	"reflect"
+	"sort"
+	"sync"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+)
+
+// CollationStrength selects which differences between strings a Collator
+// takes into account.
+type CollationStrength int
+
+const (
+	// CollateTertiary distinguishes base letters, accents and case, so
+	// "a" < "A" < "á" < "b".
+	CollateTertiary CollationStrength = iota
+	// CollateSecondary ignores case, so "a" = "A" < "á".
+	CollateSecondary
+	// CollatePrimary ignores case and accents, so "a" = "A" = "á".
+	CollatePrimary
+)
+
+// CollateOptions controls how a Collator orders strings.
+type CollateOptions struct {
+	Strength CollationStrength
+	// Numeric orders sequences of digits by their numeric value, so
+	// "file2" < "file10".
+	Numeric bool
+}
+
+// Collator compares and sorts strings following the CLDR collation of a
+// locale, e.g. with "ö" after "z" in Swedish and Chinese in pinyin order. It's
+// safe for concurrent use.
+type Collator struct {
+	mx  sync.Mutex
+	c   *collate.Collator
+	buf collate.Buffer
+}
+
+// NewCollator returns a Collator for the current locale.
+func NewCollator(opts CollateOptions) *Collator {
+	return currentLocalizer().Collator(opts)
+}
+
+// Collator returns a Collator for the locale of l.
+func (l *Localizer) Collator(opts CollateOptions) *Collator {
+	return newCollator(l.tag(), opts)
+}
+
+func newCollator(tag language.Tag, opts CollateOptions) *Collator {
+	var options []collate.Option
+	switch opts.Strength {
+	case CollateSecondary:
+		options = append(options, collate.IgnoreCase)
+	case CollatePrimary:
+		options = append(options, collate.IgnoreCase, collate.IgnoreDiacritics)
+	}
+	if opts.Numeric {
+		options = append(options, collate.Numeric)
+	}
+	return &Collator{c: collate.New(tag, options...)}
+}
+
+// Compare returns -1, 0 or 1 depending on whether a sorts before, like or
+// after b.
+func (c *Collator) Compare(a, b string) int {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	return c.c.CompareString(a, b)
+}
+
+// Sort sorts s in place.
+func (c *Collator) Sort(s []string) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	c.c.SortStrings(s)
+}
+
+// Key returns the sort key of s, which compares with bytes.Compare to the
+// keys of other strings like Compare does the strings. Computing keys once is
+// faster when sorting the same strings repeatedly, e.g. a list of countries
+// sorted by several columns.
+func (c *Collator) Key(s string) []byte {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	key := c.c.KeyFromString(&c.buf, s)
+	c.buf.Reset()
+	return append([]byte(nil), key...)
+}
+
+// SortSlice sorts slice in place, collating its elements by the string
+// which name returns for element i, e.g. the name of a country. The sort key
+// of each element is computed only once. It panics if slice isn't a slice.
+func (c *Collator) SortSlice(slice interface{}, name func(i int) string) {
+	swap := reflect.Swapper(slice)
+	n := reflect.ValueOf(slice).Len()
+	keys := make([][]byte, n)
+	for i := range keys {
+		keys[i] = c.Key(name(i))
+	}
+	sort.Stable(keyedSlice{keys, swap})
+}
+
+// keyedSlice sorts a slice by the sort keys of its elements.
+type keyedSlice struct {
+	keys [][]byte
+	swap func(i, j int)
+}
+
+func (s keyedSlice) Len() int           { return len(s.keys) }
+func (s keyedSlice) Less(i, j int) bool { return bytes.Compare(s.keys[i], s.keys[j]) < 0 }
+func (s keyedSlice) Swap(i, j int) {
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+	s.swap(i, j)
+}
@@ -0,0 +1,51 @@
+package i18n
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestCollator(t *testing.T) {
+	for _, c := range []struct {
+		locale   string
+		opts     CollateOptions
+		in       []string
+		expected []string
+	}{
+		{"en-US", CollateOptions{}, []string{"b", "A", "a", "á"}, []string{"a", "A", "á", "b"}},
+		{"de", CollateOptions{}, []string{"Zypern", "Österreich", "Oman"}, []string{"Oman", "Österreich", "Zypern"}},
+		{"sv", CollateOptions{}, []string{"Zypern", "Österreich", "Oman"}, []string{"Oman", "Zypern", "Österreich"}},
+		{"zh", CollateOptions{}, []string{"中国", "德国", "阿根廷"}, []string{"阿根廷", "德国", "中国"}},
+		{"en-US", CollateOptions{}, []string{"file10", "file2"}, []string{"file10", "file2"}},
+		{"en-US", CollateOptions{Numeric: true}, []string{"file10", "file2"}, []string{"file2", "file10"}},
+	} {
+		newCollator(language.Make(c.locale), c.opts).Sort(c.in)
+		assert.Equal(t, c.expected, c.in, "in %v", c.locale)
+	}
+
+	tertiary := newCollator(language.English, CollateOptions{})
+	secondary := newCollator(language.English, CollateOptions{Strength: CollateSecondary})
+	primary := newCollator(language.English, CollateOptions{Strength: CollatePrimary})
+	assert.Equal(t, -1, tertiary.Compare("a", "A"))
+	assert.Equal(t, 0, secondary.Compare("a", "A"))
+	assert.Equal(t, -1, secondary.Compare("a", "á"))
+	assert.Equal(t, 0, primary.Compare("A", "á"))
+	assert.Equal(t, 1, primary.Compare("b", "á"))
+
+	assert.Equal(t, -1, bytes.Compare(tertiary.Key("apple"), tertiary.Key("Banana")))
+	assert.Equal(t, 0, bytes.Compare(primary.Key("Resume"), primary.Key("résumé")))
+}
+
+func TestCollatorSortSlice(t *testing.T) {
+	type country struct {
+		code string
+		name string
+	}
+	countries := []country{{"SE", "Sverige"}, {"AT", "Österreich"}, {"AX", "Åland"}, {"DK", "Danmark"}}
+	l := &Localizer{locale: "sv"}
+	l.Collator(CollateOptions{}).SortSlice(countries, func(i int) string { return countries[i].name })
+	assert.Equal(t, []country{{"DK", "Danmark"}, {"SE", "Sverige"}, {"AX", "Åland"}, {"AT", "Österreich"}}, countries)
+}
@@ -0,0 +1,200 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// CurrencyStyle selects how FormatCurrency shows the currency.
+type CurrencyStyle int
+
+const (
+	// CurrencySymbol shows the standard symbol of the currency in the locale,
+	// e.g. "$" for USD in en-US but "US$" in zh.
+	CurrencySymbol CurrencyStyle = iota
+	// CurrencyNarrowSymbol shows the narrow symbol of the currency, e.g. "$"
+	// for both USD and CAD.
+	CurrencyNarrowSymbol
+	// CurrencyCode shows the ISO 4217 code of the currency, e.g. "USD".
+	CurrencyCode
+)
+
+// Money is an amount in a currency. As a message argument, it's formatted by
+// placeholders of type currency, e.g. {price, currency}, or without type.
+type Money struct {
+	// Amount is a Decimal, or any integer or floating point number
+	Amount interface{}
+	// Currency is the ISO 4217 code of the currency, e.g. "EUR"
+	Currency string
+}
+
+// currencyPatterns holds the CLDR currency patterns of the languages and
+// locales which don't put the symbol right before the number, with ¤ standing
+// for the symbol and {0} for the number. Locales are looked up before their
+// language.
+var currencyPatterns = map[string]string{
+	"ar":    "¤ {0}",
+	"be":    "{0} ¤",
+	"bg":    "{0} ¤",
+	"ca":    "{0} ¤",
+	"cs":    "{0} ¤",
+	"da":    "{0} ¤",
+	"de":    "{0} ¤",
+	"de-AT": "¤ {0}",
+	"de-CH": "¤ {0}",
+	"el":    "{0} ¤",
+	"es":    "{0} ¤",
+	"es-MX": "¤{0}",
+	"es-US": "¤{0}",
+	"et":    "{0} ¤",
+	"fi":    "{0} ¤",
+	"fr":    "{0} ¤",
+	"he":    "{0} ¤",
+	"hr":    "{0} ¤",
+	"hu":    "{0} ¤",
+	"it":    "{0} ¤",
+	"it-CH": "¤ {0}",
+	"kk":    "{0} ¤",
+	"lt":    "{0} ¤",
+	"lv":    "{0} ¤",
+	"nb":    "{0} ¤",
+	"nl":    "¤ {0}",
+	"pl":    "{0} ¤",
+	"pt":    "¤ {0}",
+	"pt-PT": "{0} ¤",
+	"ro":    "{0} ¤",
+	"ru":    "{0} ¤",
+	"sk":    "{0} ¤",
+	"sl":    "{0} ¤",
+	"sr":    "{0} ¤",
+	"sv":    "{0} ¤",
+	"uk":    "{0} ¤",
+	"vi":    "{0} ¤",
+}
+
+// FormatCurrency formats amount, which can be a Decimal or any integer or
+// floating point number, in the given ISO 4217 currency following the
+// conventions of the current locale, e.g. "€12.50" in en-US and "12,50 €" in
+// de. The amount is rounded to the minor unit of the currency, e.g. to no
+// fraction digits for JPY and 3 for KWD.
+func FormatCurrency(amount interface{}, currency string) string {
+	return currentLocalizer().FormatCurrency(amount, currency)
+}
+
+// FormatCurrencyStyle is like FormatCurrency, with style selecting how the
+// currency is shown.
+func FormatCurrencyStyle(amount interface{}, currency string, style CurrencyStyle) string {
+	return currentLocalizer().FormatCurrencyStyle(amount, currency, style)
+}
+
+// FormatCurrency formats amount like the package level FormatCurrency, in the
+// locale of l.
+func (l *Localizer) FormatCurrency(amount interface{}, currency string) string {
+	return formatCurrency(l.tag(), amount, currency, CurrencySymbol)
+}
+
+// FormatCurrencyStyle formats amount like the package level
+// FormatCurrencyStyle, in the locale of l.
+func (l *Localizer) FormatCurrencyStyle(amount interface{}, currency string, style CurrencyStyle) string {
+	return formatCurrency(l.tag(), amount, currency, style)
+}
+
+func formatCurrency(tag language.Tag, amount interface{}, code string, style CurrencyStyle) string {
+	d, ok := toDecimal(amount)
+	if !ok {
+		return fmt.Sprintf("%v %v", amount, code)
+	}
+	code = strings.ToUpper(code)
+	symbol, digits := code, 2
+	if unit, err := currency.ParseISO(code); err != nil {
+		log.Debugf("Unknown currency %v: %v", code, err)
+	} else {
+		digits, _ = currency.Standard.Rounding(unit)
+		p := message.NewPrinter(tag)
+		switch style {
+		case CurrencySymbol:
+			symbol = p.Sprint(currency.Symbol(unit))
+		case CurrencyNarrowSymbol:
+			symbol = p.Sprint(currency.NarrowSymbol(unit))
+		}
+	}
+
+	f := numberFormatFor(tag)
+	abs := d.round(digits)
+	neg := abs.neg
+	abs.neg = false
+	pattern := currencyPattern(tag)
+	// like CLDR currency spacing, separate symbols such as "CHF" from the
+	// number they're next to
+	if strings.HasPrefix(pattern, "¤{0}") {
+		if r, _ := utf8.DecodeLastRuneInString(symbol); unicode.IsLetter(r) {
+			pattern = strings.Replace(pattern, "¤{0}", "¤ {0}", 1)
+		}
+	} else if strings.HasSuffix(pattern, "{0}¤") {
+		if r, _ := utf8.DecodeRuneInString(symbol); unicode.IsLetter(r) {
+			pattern = strings.Replace(pattern, "{0}¤", "{0} ¤", 1)
+		}
+	}
+	s := strings.Replace(pattern, "{0}", f.format(abs, digits), 1)
+	s = strings.Replace(s, "¤", symbol, 1)
+	if neg {
+		s = f.minusPrefix + s + f.minusSuffix
+	}
+	return s
+}
+
+// currencyPattern returns the CLDR currency pattern of tag.
+func currencyPattern(tag language.Tag) string {
+	base, _ := tag.Base()
+	if region, conf := tag.Region(); conf == language.Exact {
+		if pattern, found := currencyPatterns[base.String()+"-"+region.String()]; found {
+			return pattern
+		}
+	}
+	if pattern, found := currencyPatterns[base.String()]; found {
+		return pattern
+	}
+	return "¤{0}"
+}
+
+// parseCurrencyStyle parses the style of a currency placeholder, made of an
+// optional ISO 4217 code and an optional "symbol", "narrow" or "code", e.g.
+// "EUR narrow".
+func parseCurrencyStyle(s string) (code string, style CurrencyStyle, err error) {
+	for _, field := range strings.Fields(s) {
+		switch field {
+		case "symbol":
+			style = CurrencySymbol
+		case "narrow":
+			style = CurrencyNarrowSymbol
+		case "code":
+			style = CurrencyCode
+		default:
+			if _, err := currency.ParseISO(field); err != nil {
+				return "", style, fmt.Errorf("unknown currency %q", field)
+			}
+			code = field
+		}
+	}
+	return code, style, nil
+}
+
+// formatCurrencyArg formats the argument of a currency placeholder, which is
+// either Money or an amount in the currency of the style.
+func formatCurrencyArg(tag language.Tag, v interface{}, s string) string {
+	code, style, _ := parseCurrencyStyle(s)
+	if m, ok := v.(Money); ok {
+		return formatCurrency(tag, m.Amount, m.Currency, style)
+	}
+	if code == "" {
+		log.Debugf("No currency for amount %v", v)
+		return formatNumber(tag, v, "")
+	}
+	return formatCurrency(tag, v, code, style)
+}
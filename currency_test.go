@@ -0,0 +1,54 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestFormatCurrency(t *testing.T) {
+	price, err := ParseDecimal("1234.5")
+	if !assert.NoError(t, err) {
+		return
+	}
+	for _, c := range []struct {
+		locale   string
+		amount   interface{}
+		currency string
+		style    CurrencyStyle
+		expected string
+	}{
+		{"en-US", price, "USD", CurrencySymbol, "$1,234.50"},
+		{"en-US", price, "EUR", CurrencySymbol, "€1,234.50"},
+		{"en-US", price, "CAD", CurrencySymbol, "CA$1,234.50"},
+		{"en-US", price, "CAD", CurrencyNarrowSymbol, "$1,234.50"},
+		{"en-US", price, "CHF", CurrencySymbol, "CHF 1,234.50"},
+		{"en-US", price, "USD", CurrencyCode, "USD 1,234.50"},
+		{"en-US", NewDecimal(-1999, 2), "USD", CurrencySymbol, "-$19.99"},
+		{"de", price, "EUR", CurrencySymbol, "1.234,50 €"},
+		{"de-CH", price, "CHF", CurrencySymbol, "CHF 1’234.50"},
+		{"fr", -12.5, "EUR", CurrencySymbol, "-12,50 €"},
+		{"zh", price, "USD", CurrencySymbol, "US$1,234.50"},
+		{"ja", 1234.5, "JPY", CurrencySymbol, "￥1,234"},
+		{"en-US", NewDecimal(12345, 3), "KWD", CurrencyCode, "KWD 12.345"},
+		{"en-US", NewDecimal(12346, 3), "XYZ", CurrencySymbol, "XYZ 12.35"},
+		{"en-US", "free", "USD", CurrencySymbol, "free USD"},
+	} {
+		assert.Equal(t, c.expected, formatCurrency(language.Make(c.locale), c.amount, c.currency, c.style), "%v %v in %v", c.amount, c.currency, c.locale)
+	}
+	big, _ := ParseDecimal("98765432109876543.21")
+	assert.Equal(t, "$98,765,432,109,876,543.21", formatCurrency(language.AmericanEnglish, big, "USD", CurrencySymbol), "should be exact")
+}
+
+func TestCurrencyPlaceholder(t *testing.T) {
+	l := &Localizer{locale: "de", messages: map[string]string{
+		"PRICE":  "Nur {price, currency} statt {0, currency, EUR}",
+		"NARROW": "{0, currency, CAD narrow}",
+	}}
+	assert.Equal(t, "Nur 9,99 $ statt 12,00 €", l.T("PRICE", 12, Params{"price": Money{Amount: NewDecimal(999, 2), Currency: "USD"}}))
+	assert.Equal(t, "5,00 $", l.T("NARROW", 5))
+
+	_, err := Placeholders("{0, currency, EURO}")
+	assert.Error(t, err, "should not accept unknown currencies")
+}
@@ -0,0 +1,387 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// dateLengths are the CLDR length styles, in the order of calendar patterns.
+var dateLengths = map[string]int{"full": 0, "long": 1, "medium": 2, "short": 3}
+
+// FormatDate formats the date of t in the current locale. style is one of the
+// CLDR lengths "short", "medium", "long" and "full", e.g. "Jan 2, 2006" for
+// medium in en-US and "2. Januar 2006" for long in de, or a CLDR skeleton
+// listing the fields to show, e.g. "yMMMd". Skeletons can add a time to the
+// date, e.g. "yMMMdjm". An unknown style is formatted as medium.
+//
+// Supported date skeletons are yMd, yMMM, yMMMd, yMMMEd, yMMMM, MMMd, MMMEd,
+// MMMMd, Md and any single field. Time skeletons are made of an hour, h for a
+// 12-hour clock, H for a 24-hour clock or j for the clock preferred in the
+// region of the locale, followed by m, optionally s and optionally z or zzzz
+// for the time zone.
+//
+// Month and weekday names are those of CLDR for the languages with compact
+// number formats, and English elsewhere. Time zones are shown by name in
+// English and by their offset from GMT in other languages.
+func FormatDate(t time.Time, style string) string {
+	return currentLocalizer().FormatDate(t, style)
+}
+
+// FormatTime formats the time of day of t in the current locale, with style
+// a CLDR length or a skeleton like for FormatDate, e.g. "3:04 PM" for short in
+// en-US and "15:04" in de. long and full lengths show the time zone of t.
+func FormatTime(t time.Time, style string) string {
+	return currentLocalizer().FormatTime(t, style)
+}
+
+// FormatDateTime formats both the date and the time of t in the current
+// locale, with style a CLDR length or a skeleton like for FormatDate, e.g.
+// "Jan 2, 2006, 3:04:05 PM" for medium in en-US.
+func FormatDateTime(t time.Time, style string) string {
+	return currentLocalizer().FormatDateTime(t, style)
+}
+
+// FormatDate formats t like the package level FormatDate, in the locale of l.
+func (l *Localizer) FormatDate(t time.Time, style string) string {
+	return formatDateTime(l.tag(), t, "date", style)
+}
+
+// FormatTime formats t like the package level FormatTime, in the locale of l.
+func (l *Localizer) FormatTime(t time.Time, style string) string {
+	return formatDateTime(l.tag(), t, "time", style)
+}
+
+// FormatDateTime formats t like the package level FormatDateTime, in the
+// locale of l.
+func (l *Localizer) FormatDateTime(t time.Time, style string) string {
+	return formatDateTime(l.tag(), t, "datetime", style)
+}
+
+// formatDateTime formats the parts of t selected by kind, which is "date",
+// "time" or "datetime", in the given style.
+func formatDateTime(tag language.Tag, t time.Time, kind string, style string) string {
+	c, hour12 := calendarFor(tag)
+	pattern, err := c.pattern(kind, style, hour12)
+	if err != nil {
+		log.Debugf("Unable to format %v as %v: %v", kind, style, err)
+		pattern, _ = c.pattern(kind, "medium", hour12)
+	}
+	return c.format(numberFormatFor(tag), pattern, t)
+}
+
+// calendarFor returns the calendar of tag, and whether its region prefers a
+// 12-hour clock.
+func calendarFor(tag language.Tag) (*calendar, bool) {
+	base, _ := tag.Base()
+	region, _ := tag.Region()
+	hour12 := hour12Regions[region.String()]
+	if c, found := calendars[base.String()+"-"+region.String()]; found {
+		return c, hour12
+	}
+	if c, found := calendars[base.String()]; found {
+		return c, hour12
+	}
+	return calendars["en"], hour12
+}
+
+// pattern returns the CLDR pattern formatting the parts of a time selected by
+// kind in the given style, which is either a length or a skeleton.
+func (c *calendar) pattern(kind string, style string, hour12 bool) (string, error) {
+	i, isLength := dateLengths[style]
+	if !isLength {
+		return c.skeleton(style, hour12)
+	}
+	switch kind {
+	case "date":
+		return c.dates[i], nil
+	case "time":
+		return c.timePattern(i, hour12), nil
+	default:
+		return strings.NewReplacer("{1}", c.dates[i], "{0}", c.timePattern(i, hour12)).Replace(c.dateTime[i]), nil
+	}
+}
+
+// timePattern returns the time pattern of the length at index i of the CLDR
+// lengths, with seconds unless it's short and the time zone if it's long or
+// full.
+func (c *calendar) timePattern(i int, hour12 bool) string {
+	pattern := c.Hm
+	if hour12 {
+		pattern = c.hm
+	}
+	if i < dateLengths["short"] {
+		// seconds follow the separator of minutes, e.g. HH.mm.ss in Danish
+		sep := ":"
+		if j := strings.Index(pattern, "mm"); j > 0 && pattern[j-1] == '.' {
+			sep = "."
+		}
+		pattern = strings.Replace(pattern, "mm", "mm"+sep+"ss", 1)
+	}
+	switch i {
+	case dateLengths["full"]:
+		pattern += " zzzz"
+	case dateLengths["long"]:
+		pattern += " z"
+	}
+	return pattern
+}
+
+// skeleton returns the pattern of a CLDR skeleton.
+func (c *calendar) skeleton(skeleton string, hour12 bool) (string, error) {
+	i := strings.IndexAny(skeleton, "jhHm")
+	if i < 0 {
+		i = len(skeleton)
+	}
+	date, clock := skeleton[:i], skeleton[i:]
+	if date == "" && clock == "" {
+		return "", fmt.Errorf("empty skeleton")
+	}
+	var datePattern, timePattern string
+	if date != "" {
+		var found bool
+		if datePattern, found = c.skeletons[date]; !found {
+			if !isSingleField(date) {
+				return "", fmt.Errorf("unsupported date skeleton %q", date)
+			}
+			datePattern = strings.NewReplacer("MMMM", "LLLL", "MMM", "LLL").Replace(date)
+		}
+	}
+	if clock != "" {
+		switch clock[0] {
+		case 'h':
+			hour12 = true
+		case 'H':
+			hour12 = false
+		case 'j':
+		default:
+			return "", fmt.Errorf("time skeleton %q should start with the hour", clock)
+		}
+		fields, zone := clock[1:], ""
+		if i := strings.IndexByte(fields, 'z'); i >= 0 {
+			fields, zone = fields[:i], fields[i:]
+		}
+		switch {
+		case zone != "" && zone != "z" && zone != "zzzz":
+			return "", fmt.Errorf("unsupported time zone field %q", zone)
+		case fields == "m":
+			timePattern = c.timePattern(dateLengths["short"], hour12)
+		case fields == "ms":
+			timePattern = c.timePattern(dateLengths["medium"], hour12)
+		default:
+			return "", fmt.Errorf("unsupported time skeleton %q", clock)
+		}
+		if zone != "" {
+			timePattern += " " + zone
+		}
+	}
+	switch {
+	case timePattern == "":
+		return datePattern, nil
+	case datePattern == "":
+		return timePattern, nil
+	}
+	// like CLDR, glue the date and the time by the length the date resembles
+	length := dateLengths["short"]
+	switch {
+	case strings.Contains(date, "MMMM") && strings.Contains(date, "E"):
+		length = dateLengths["full"]
+	case strings.Contains(date, "MMMM"):
+		length = dateLengths["long"]
+	case strings.Contains(date, "MMM"):
+		length = dateLengths["medium"]
+	}
+	return strings.NewReplacer("{1}", datePattern, "{0}", timePattern).Replace(c.dateTime[length]), nil
+}
+
+// isSingleField reports whether a skeleton is a single date field such as y or
+// MMMM.
+func isSingleField(skeleton string) bool {
+	if strings.Trim(skeleton, skeleton[:1]) != "" {
+		return false
+	}
+	return strings.Contains("yMLdEc", skeleton[:1])
+}
+
+// format formats t following a CLDR date pattern, where letters are fields,
+// e.g. MMM for the abbreviated month, and text between apostrophes is literal.
+func (c *calendar) format(f *numberFormat, pattern string, t time.Time) string {
+	var b strings.Builder
+	for i := 0; i < len(pattern); {
+		ch := pattern[i]
+		switch {
+		case ch == '\'' && i+1 < len(pattern) && pattern[i+1] == '\'':
+			b.WriteByte('\'')
+			i += 2
+		case ch == '\'':
+			end := strings.IndexByte(pattern[i+1:], '\'')
+			if end < 0 {
+				b.WriteString(pattern[i+1:])
+				return b.String()
+			}
+			b.WriteString(pattern[i+1 : i+1+end])
+			i += end + 2
+		case ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z':
+			n := 1
+			for i+n < len(pattern) && pattern[i+n] == ch {
+				n++
+			}
+			c.formatField(&b, f, ch, n, t)
+			i += n
+		default:
+			b.WriteByte(ch)
+			i++
+		}
+	}
+	return b.String()
+}
+
+// formatField formats the field of t shown by n repetitions of the letter ch.
+func (c *calendar) formatField(b *strings.Builder, f *numberFormat, ch byte, n int, t time.Time) {
+	number := func(v int, width int) {
+		s := fmt.Sprintf("%0*d", width, v)
+		for i := 0; i < len(s); i++ {
+			b.WriteString(f.digits[s[i]-'0'])
+		}
+	}
+	switch ch {
+	case 'y':
+		if n == 2 {
+			number(t.Year()%100, 2)
+		} else {
+			number(t.Year(), n)
+		}
+	case 'M', 'L':
+		months, abbr := c.months, c.monthsAbbr
+		if ch == 'L' && c.standaloneMonths[0] != "" {
+			months, abbr = c.standaloneMonths, c.standaloneMonthsAbbr
+		}
+		switch {
+		case n <= 2:
+			number(int(t.Month()), n)
+		case n == 3:
+			b.WriteString(abbr[t.Month()-1])
+		default:
+			b.WriteString(months[t.Month()-1])
+		}
+	case 'd':
+		number(t.Day(), n)
+	case 'E', 'c':
+		if n <= 3 {
+			b.WriteString(c.weekdaysAbbr[t.Weekday()])
+		} else {
+			b.WriteString(c.weekdays[t.Weekday()])
+		}
+	case 'a':
+		if t.Hour() < 12 {
+			b.WriteString(c.am)
+		} else {
+			b.WriteString(c.pm)
+		}
+	case 'h':
+		h := t.Hour() % 12
+		if h == 0 {
+			h = 12
+		}
+		number(h, n)
+	case 'K':
+		number(t.Hour()%12, n)
+	case 'H':
+		number(t.Hour(), n)
+	case 'k':
+		h := t.Hour()
+		if h == 0 {
+			h = 24
+		}
+		number(h, n)
+	case 'm':
+		number(t.Minute(), n)
+	case 's':
+		number(t.Second(), n)
+	case 'z', 'v':
+		b.WriteString(c.zoneName(f, t, n >= 4))
+	case 'O':
+		b.WriteString(c.gmtOffset(f, t, n >= 4))
+	default:
+		b.WriteString(strings.Repeat(string(ch), n))
+	}
+}
+
+// zoneName returns the name of the time zone of t, which is its long name in
+// the language of the calendar, or its abbreviation if the calendar uses them,
+// and its offset from GMT otherwise.
+func (c *calendar) zoneName(f *numberFormat, t time.Time, long bool) string {
+	abbr, offset := t.Zone()
+	if long {
+		if name, found := c.zones[zone{abbr, offset}]; found {
+			return name
+		}
+	} else if c.zoneAbbrs && abbr != "" && strings.Trim(abbr, "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz") == "" {
+		return abbr
+	}
+	return c.gmtOffset(f, t, long)
+}
+
+// gmtOffset returns the localized GMT format of the offset of t, e.g. "GMT-8"
+// or, if long, "GMT-08:00".
+func (c *calendar) gmtOffset(f *numberFormat, t time.Time, long bool) string {
+	_, offset := t.Zone()
+	if offset == 0 {
+		return c.gmtZero
+	}
+	sign := "+"
+	if offset < 0 {
+		sign, offset = "-", -offset
+	}
+	hours, minutes := offset/3600, offset/60%60
+	var s string
+	switch {
+	case long:
+		s = fmt.Sprintf("%02d:%02d", hours, minutes)
+	case minutes != 0:
+		s = fmt.Sprintf("%d:%02d", hours, minutes)
+	default:
+		s = fmt.Sprintf("%d", hours)
+	}
+	var digits strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] >= '0' && s[i] <= '9' {
+			digits.WriteString(f.digits[s[i]-'0'])
+		} else {
+			digits.WriteByte(s[i])
+		}
+	}
+	return strings.Replace(c.gmt, "{0}", sign+digits.String(), 1)
+}
+
+// parseDateStyle validates the style of a date or time placeholder, which is
+// empty for medium, a CLDR length or a skeleton prefixed with "::", e.g.
+// {when, date, ::yMMMd}, and returns the style to format it with.
+func parseDateStyle(style string) (string, error) {
+	if style == "" {
+		return "medium", nil
+	}
+	if _, isLength := dateLengths[style]; isLength {
+		return style, nil
+	}
+	if !strings.HasPrefix(style, "::") {
+		return "", fmt.Errorf("unknown date style %q", style)
+	}
+	skeleton := strings.TrimPrefix(style, "::")
+	if _, err := calendars["en"].skeleton(skeleton, false); err != nil {
+		return "", err
+	}
+	return skeleton, nil
+}
+
+// formatDateArg formats the argument of a date or time placeholder.
+func formatDateArg(tag language.Tag, v interface{}, kind string, style string) string {
+	t, ok := v.(time.Time)
+	if !ok {
+		return fmt.Sprint(v)
+	}
+	style, _ = parseDateStyle(style)
+	return formatDateTime(tag, t, kind, style)
+}
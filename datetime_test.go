@@ -0,0 +1,131 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestFormatDateTime(t *testing.T) {
+	pst := time.FixedZone("PST", -8*3600)
+	when := time.Date(2006, time.January, 2, 15, 4, 5, 0, pst)
+	for _, c := range []struct {
+		locale   string
+		kind     string
+		style    string
+		expected string
+	}{
+		{"en-US", "date", "short", "1/2/06"},
+		{"en-US", "date", "medium", "Jan 2, 2006"},
+		{"en-US", "date", "long", "January 2, 2006"},
+		{"en-US", "date", "full", "Monday, January 2, 2006"},
+		{"en-US", "time", "short", "3:04 PM"},
+		{"en-US", "time", "long", "3:04:05 PM PST"},
+		{"en-US", "time", "full", "3:04:05 PM Pacific Standard Time"},
+		{"en-US", "datetime", "medium", "Jan 2, 2006, 3:04:05 PM"},
+		{"en-US", "datetime", "long", "January 2, 2006 at 3:04:05 PM PST"},
+		{"en-GB", "date", "medium", "2 Jan 2006"},
+		{"en-GB", "time", "short", "15:04"},
+		{"de", "date", "long", "2. Januar 2006"},
+		{"de", "date", "short", "02.01.06"},
+		{"de", "datetime", "full", "Montag, 2. Januar 2006 um 15:04:05 GMT-08:00"},
+		{"de", "time", "long", "15:04:05 GMT-8"},
+		{"fr", "date", "full", "lundi 2 janvier 2006"},
+		{"es", "date", "long", "2 de enero de 2006"},
+		{"ru", "date", "long", "2 января 2006 г."},
+		{"ru", "date", "yMMMM", "январь 2006 г."},
+		{"zh", "date", "full", "2006年1月2日星期一"},
+		{"zh", "time", "short", "15:04"},
+		{"zh-TW", "time", "short", "下午3:04"},
+		{"ja", "date", "yMMMEd", "2006年1月2日(月)"},
+		{"ja", "time", "hm", "午後3:04"},
+		{"ko", "time", "short", "오후 3:04"},
+		{"vi", "datetime", "short", "15:04 02/01/2006"},
+		{"cs", "date", "full", "pondělí 2. ledna 2006"},
+		{"cs", "date", "yMMMM", "leden 2006"},
+		{"da", "datetime", "full", "mandag den 2. januar 2006 kl. 15.04.05 GMT-08:00"},
+		{"fi", "date", "full", "maanantai 2. tammikuuta 2006"},
+		{"fi", "date", "yMMMM", "tammikuu 2006"},
+		{"nb", "date", "medium", "2. jan. 2006"},
+		{"nl", "date", "long", "2 januari 2006"},
+		{"pl", "date", "long", "2 stycznia 2006"},
+		{"pl", "date", "yMMMM", "styczeń 2006"},
+		{"sv", "date", "short", "2006-01-02"},
+		{"uk", "date", "long", "2 січня 2006 р."},
+		{"he", "date", "full", "יום שני, 2 בינואר 2006"},
+		{"he", "datetime", "short", "2.1.2006, 15:04"},
+		{"zh", "time", "full", "15:04:05 北美太平洋标准时间"},
+		{"en-US", "date", "MMMMd", "January 2"},
+		{"en-US", "date", "yMMMdjm", "Jan 2, 2006, 3:04 PM"},
+		{"en-US", "date", "yMdHmsz", "1/2/2006, 15:04:05 PST"},
+		{"en-US", "date", "EEEE", "Monday"},
+		{"en-US", "date", "MMMM", "January"},
+		{"en-US", "date", "bogus", "Jan 2, 2006"},
+		{"xx", "date", "medium", "Jan 2, 2006"},
+	} {
+		assert.Equal(t, c.expected, formatDateTime(language.Make(c.locale), when, c.kind, c.style), "%v %v in %v", c.kind, c.style, c.locale)
+	}
+
+	utc := time.Date(2006, time.January, 2, 15, 4, 5, 0, time.UTC)
+	assert.Equal(t, "15:04:05 UTC", formatDateTime(language.French, utc, "time", "long"))
+	india := time.Date(2006, time.January, 2, 15, 4, 5, 0, time.FixedZone("+0530", 19800))
+	assert.Equal(t, "15:04:05 GMT+5:30", formatDateTime(language.German, india, "time", "long"))
+	assert.Equal(t, "३:०४ pm", formatDateTime(localeTag("hi", true), when, "time", "short"), "should use native digits")
+}
+
+func TestDatePlaceholder(t *testing.T) {
+	l := &Localizer{locale: "de-DE", messages: map[string]string{
+		"SENT":    "Gesendet am {when, date, long} um {when, time, short}",
+		"SKETCH":  "{0, date, ::MMMd}",
+		"DEFAULT": "{0}",
+	}}
+	when := time.Date(2006, time.January, 2, 15, 4, 5, 0, time.UTC)
+	assert.Equal(t, "Gesendet am 2. Januar 2006 um 15:04", l.T("SENT", Params{"when": when}))
+	assert.Equal(t, "2. Jan.", l.T("SKETCH", when))
+	assert.Equal(t, "02.01.06, 15:04", l.T("DEFAULT", when))
+	assert.Equal(t, "2. Januar 2006", l.FormatDate(when, "long"))
+
+	_, err := Placeholders("{0, date, shortest}")
+	assert.Error(t, err)
+	_, err = Placeholders("{0, date, ::yMMMMQ}")
+	assert.Error(t, err)
+}
+
+func TestCalendarsCoverLocales(t *testing.T) {
+	when := time.Date(2006, time.January, 2, 15, 4, 5, 0, time.FixedZone("PST", -8*3600))
+	for _, locale := range coveredLocales(t) {
+		s := formatDateTime(language.Make(locale), when, "datetime", "full")
+		for _, english := range []string{"January", "Monday", "Pacific", "PM"} {
+			assert.NotContains(t, s, english, "%s should not fall back to English", locale)
+		}
+	}
+}
+
+// coveredLocales returns the locales which the CLDR data should cover but
+// English: those of the translation files, of the ordinals, of the calendars
+// and the right-to-left languages.
+func coveredLocales(t *testing.T) []string {
+	defer restoreState()()
+	SetMessagesDir("locale")
+	available, err := AvailableLocales()
+	assert.NoError(t, err)
+	locales := []string{"ar", "fa", "he"}
+	for _, l := range available {
+		locales = append(locales, l.Locale)
+	}
+	for lang := range ordinalPatterns {
+		locales = append(locales, lang)
+	}
+	for lang := range calendars {
+		locales = append(locales, lang)
+	}
+	var covered []string
+	for _, locale := range locales {
+		if base, _ := language.Make(locale).Base(); base.String() != "en" {
+			covered = append(covered, locale)
+		}
+	}
+	return covered
+}
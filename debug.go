@@ -0,0 +1,134 @@
+package i18n
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// DebugInfo is a snapshot of the translations currently in use, as served by
+// DebugHandler.
+type DebugInfo struct {
+	Locale    string   `json:"locale"`
+	Fallbacks []string `json:"fallbacks"`
+	// Files lists the translation files loaded for Locale, from the lowest
+	// precedence to the highest
+	Files    []string       `json:"files"`
+	Messages []DebugMessage `json:"messages"`
+	Stats    *LocaleStats   `json:"stats,omitempty"`
+	Query    string         `json:"query,omitempty"`
+}
+
+// DebugMessage is a single translation along with the layer and file which
+// supplied it.
+type DebugMessage struct {
+	Key string `json:"key"`
+	// Context is the context of the message, if it's looked up with TC
+	Context string `json:"context,omitempty"`
+	// Register is formal or informal for the variants of the message
+	Register string `json:"register,omitempty"`
+	Value    string `json:"value"`
+	Origin   string `json:"origin"`
+}
+
+// DebugHandler returns an http.Handler showing the current locale, its
+// fallback chain and every translation in use along with the file it came
+// from. The q query parameter filters translations whose key or value contains
+// it, case insensitively. The information is served as JSON if the format
+// query parameter is "json" or the request accepts application/json, and as
+// an HTML page otherwise.
+func DebugHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		query := req.FormValue("q")
+		info := debugInfo(query)
+		if req.FormValue("format") == "json" || strings.Contains(req.Header.Get("Accept"), "application/json") {
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			enc := json.NewEncoder(w)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(info); err != nil {
+				log.Debugf("Unable to write debug info: %v", err)
+			}
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := debugTemplate.Execute(w, info); err != nil {
+			log.Debugf("Unable to write debug page: %v", err)
+		}
+	})
+}
+
+func debugInfo(query string) *DebugInfo {
+	l := currentLocalizer()
+	info := &DebugInfo{
+		Locale:    l.locale,
+		Fallbacks: l.fallbacks,
+		Files:     l.files,
+		Query:     query,
+	}
+	q := strings.ToLower(query)
+	for k, v := range l.messages {
+		if q == "" || strings.Contains(strings.ToLower(k), q) || strings.Contains(strings.ToLower(v), q) {
+			key, register := splitRegisterKey(k)
+			context, key := SplitContextKey(key)
+			m := DebugMessage{Key: key, Context: context, Value: v, Origin: l.origins[k]}
+			if register != RegisterNeutral {
+				m.Register = register.String()
+			}
+			info.Messages = append(info.Messages, m)
+		}
+	}
+	sort.Slice(info.Messages, func(i, j int) bool {
+		a, b := info.Messages[i], info.Messages[j]
+		if a.Key != b.Key {
+			return a.Key < b.Key
+		}
+		if a.Context != b.Context {
+			return a.Context < b.Context
+		}
+		return a.Register < b.Register
+	})
+	if info.Locale != "" {
+		if stats, err := Stats(info.Locale); err != nil {
+			log.Debugf("Unable to compute stats of %s: %v", info.Locale, err)
+		} else {
+			info.Stats = stats
+		}
+	}
+	return info
+}
+
+var debugTemplate = template.Must(template.New("debug").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>i18n</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; }
+td, th { border: 1px solid #ccc; padding: 2px 6px; text-align: left; vertical-align: top; }
+</style>
+</head>
+<body>
+<h1>i18n</h1>
+{{if .Locale}}
+<p>Locale: <b>{{.Locale}}</b>, fallbacks: {{range $i, $l := .Fallbacks}}{{if $i}} &rarr; {{end}}{{$l}}{{end}}</p>
+<p>Files loaded: {{range $i, $f := .Files}}{{if $i}}, {{end}}{{$f}}{{end}}</p>
+{{with .Stats}}<p>{{.Translated}} of {{.Total}} keys translated, {{.Fallback}} from fallbacks, {{.Empty}} empty, {{.Identical}} identical to default.</p>{{end}}
+{{else}}
+<p>No locale set.</p>
+{{end}}
+<form method="get">
+<input type="search" name="q" value="{{.Query}}" placeholder="Search keys and values">
+<input type="submit" value="Search">
+<a href="?format=json&amp;q={{.Query}}">JSON</a>
+</form>
+<table>
+<tr><th>Key</th><th>Value</th><th>Origin</th></tr>
+{{range .Messages}}<tr><td>{{.Key}}{{with .Context}} <i>({{.}})</i>{{end}}{{with .Register}} <i>[{{.}}]</i>{{end}}</td><td>{{.Value}}</td><td>{{.Origin}}</td></tr>
+{{end}}
+</table>
+</body>
+</html>
+`))
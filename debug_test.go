@@ -0,0 +1,38 @@
+package i18n
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebugHandler(t *testing.T) {
+	defer restoreState()()
+	SetMessagesDir("locale")
+	if !assert.NoError(t, setLocale("zh_CN")) {
+		return
+	}
+	h := DebugHandler()
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/debug/i18n?format=json&q=speak", nil))
+	var info DebugInfo
+	if assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &info)) {
+		assert.Equal(t, "zh-CN", info.Locale)
+		assert.Equal(t, []string{"zh-CN", "zh", "en-US", "en"}, info.Fallbacks)
+		assert.Equal(t, []string{"en.json", "en-US.json", "zh.json", "zh-CN.json"}, info.Files)
+		assert.Len(t, info.Messages, 4, "should only list messages matching the query")
+		assert.Equal(t, DebugMessage{Key: "ONLY_IN_EN", Value: "I speak Generic English!", Origin: "en.json"}, info.Messages[0])
+		if assert.NotNil(t, info.Stats) {
+			assert.Equal(t, 4, info.Stats.Total)
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/debug/i18n?q=hello", nil))
+	assert.Equal(t, "text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), "<td>HELLO</td><td>%s你好!</td><td>zh.json</td>")
+	assert.NotContains(t, rec.Body.String(), "ONLY_IN_EN")
+}
@@ -0,0 +1,251 @@
+package i18n
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+)
+
+// AvailableLocale is a locale for which the message source has translations.
+type AvailableLocale struct {
+	// Locale is the locale as passed to SetLocale, e.g. "zh-CN".
+	Locale string
+	// Name is the name of the locale in its own language, as shown in
+	// language pickers, e.g. "中文（中国）".
+	Name string
+}
+
+// localePatterns are the CLDR patterns combining a language with its script
+// and region, as {0} ({1}), and joining the script and region.
+type localePatterns struct {
+	pattern, separator string
+}
+
+var localeDisplayPatterns = map[string]localePatterns{
+	"ar": {"{0} ({1})", "{0}، {1}"},
+	"fa": {"{0} ({1})", "{0}،‏ {1}"},
+	"ja": {"{0} ({1})", "{0}、{1}"},
+	"ko": {"{0}({1})", "{0}, {1}"},
+	"zh": {"{0}（{1}）", "{0}，{1}"},
+}
+
+// scriptDisplayNames are the CLDR names of scripts within the name of a
+// locale, where x/text only has standalone names such as "Simplified Han".
+var scriptDisplayNames = map[string]map[string]string{
+	"de": {"Hans": "vereinfacht", "Hant": "traditionell"},
+	"en": {"Hans": "Simplified", "Hant": "Traditional"},
+	"es": {"Hans": "simplificado", "Hant": "tradicional"},
+	"fr": {"Hans": "simplifié", "Hant": "traditionnel"},
+	"it": {"Hans": "semplificato", "Hant": "tradizionale"},
+	"ja": {"Hans": "簡体字", "Hant": "繁体字"},
+	"ko": {"Hans": "간체", "Hant": "번체"},
+	"pt": {"Hans": "simplificado", "Hant": "tradicional"},
+	"ru": {"Hans": "упрощенный", "Hant": "традиционный"},
+	"zh": {"Hans": "简体", "Hant": "繁体"},
+}
+
+// currencyNames are the CLDR display names of common currencies, which x/text
+// doesn't provide.
+var currencyNames = map[string]map[string]string{
+	"de": {
+		"AUD": "Australischer Dollar", "BRL": "Brasilianischer Real", "CAD": "Kanadischer Dollar", "CHF": "Schweizer Franken",
+		"CNY": "Renminbi Yuan", "EUR": "Euro", "GBP": "Britisches Pfund", "INR": "Indische Rupie", "JPY": "Japanischer Yen",
+		"KRW": "Südkoreanischer Won", "MXN": "Mexikanischer Peso", "RUB": "Russischer Rubel", "USD": "US-Dollar",
+	},
+	"en": {
+		"AUD": "Australian Dollar", "BRL": "Brazilian Real", "CAD": "Canadian Dollar", "CHF": "Swiss Franc",
+		"CNY": "Chinese Yuan", "EUR": "Euro", "GBP": "British Pound", "INR": "Indian Rupee", "JPY": "Japanese Yen",
+		"KRW": "South Korean Won", "MXN": "Mexican Peso", "RUB": "Russian Ruble", "USD": "US Dollar",
+	},
+	"es": {
+		"AUD": "dólar australiano", "BRL": "real brasileño", "CAD": "dólar canadiense", "CHF": "franco suizo",
+		"CNY": "yuan", "EUR": "euro", "GBP": "libra esterlina", "INR": "rupia india", "JPY": "yen",
+		"KRW": "won surcoreano", "MXN": "peso mexicano", "RUB": "rublo ruso", "USD": "dólar estadounidense",
+	},
+	"fr": {
+		"AUD": "dollar australien", "BRL": "réal brésilien", "CAD": "dollar canadien", "CHF": "franc suisse",
+		"CNY": "yuan renminbi chinois", "EUR": "euro", "GBP": "livre sterling", "INR": "roupie indienne", "JPY": "yen japonais",
+		"KRW": "won sud-coréen", "MXN": "peso mexicain", "RUB": "rouble russe", "USD": "dollar des États-Unis",
+	},
+	"it": {
+		"AUD": "dollaro australiano", "BRL": "real brasiliano", "CAD": "dollaro canadese", "CHF": "franco svizzero",
+		"CNY": "renminbi cinese", "EUR": "euro", "GBP": "sterlina britannica", "INR": "rupia indiana", "JPY": "yen giapponese",
+		"KRW": "won sudcoreano", "MXN": "peso messicano", "RUB": "rublo russo", "USD": "dollaro statunitense",
+	},
+	"ja": {
+		"AUD": "オーストラリア ドル", "BRL": "ブラジル レアル", "CAD": "カナダ ドル", "CHF": "スイス フラン",
+		"CNY": "中国人民元", "EUR": "ユーロ", "GBP": "英国ポンド", "INR": "インド ルピー", "JPY": "日本円",
+		"KRW": "韓国ウォン", "MXN": "メキシコ ペソ", "RUB": "ロシア ルーブル", "USD": "米ドル",
+	},
+	"ko": {
+		"AUD": "호주 달러", "BRL": "브라질 레알", "CAD": "캐나다 달러", "CHF": "스위스 프랑",
+		"CNY": "중국 위안화", "EUR": "유로", "GBP": "영국 파운드", "INR": "인도 루피", "JPY": "일본 엔화",
+		"KRW": "대한민국 원", "MXN": "멕시코 페소", "RUB": "러시아 루블", "USD": "미국 달러",
+	},
+	"pt": {
+		"AUD": "Dólar australiano", "BRL": "Real brasileiro", "CAD": "Dólar canadense", "CHF": "Franco suíço",
+		"CNY": "Yuan chinês", "EUR": "Euro", "GBP": "Libra esterlina", "INR": "Rupia indiana", "JPY": "Iene japonês",
+		"KRW": "Won sul-coreano", "MXN": "Peso mexicano", "RUB": "Rublo russo", "USD": "Dólar americano",
+	},
+	"ru": {
+		"AUD": "австралийский доллар", "BRL": "бразильский реал", "CAD": "канадский доллар", "CHF": "швейцарский франк",
+		"CNY": "китайский юань", "EUR": "евро", "GBP": "британский фунт стерлингов", "INR": "индийская рупия", "JPY": "японская иена",
+		"KRW": "южнокорейская вона", "MXN": "мексиканский песо", "RUB": "российский рубль", "USD": "доллар США",
+	},
+	"zh": {
+		"AUD": "澳大利亚元", "BRL": "巴西雷亚尔", "CAD": "加拿大元", "CHF": "瑞士法郎",
+		"CNY": "人民币", "EUR": "欧元", "GBP": "英镑", "INR": "印度卢比", "JPY": "日元",
+		"KRW": "韩元", "MXN": "墨西哥比索", "RUB": "俄罗斯卢布", "USD": "美元",
+	},
+}
+
+var (
+	displayTags    = display.Supported.Tags()
+	displayMatcher = language.NewMatcher(displayTags)
+)
+
+// DisplayName returns the name of a locale, language, script, region or
+// currency in the language of inLocale, e.g. "Deutsch" for "de" in de,
+// "中文（简体）" for "zh-Hans" in zh and "English (United States)" for "en_US"
+// in en. x is either a locale string as passed to SetLocale, or a
+// language.Tag, language.Base, language.Script, language.Region or
+// currency.Unit. Names which aren't known in the language of inLocale are
+// given in English, or else as their code.
+func DisplayName(x interface{}, inLocale string) string {
+	return displayName(localeTag(strings.Replace(inLocale, "_", "-", -1), false), x)
+}
+
+// DisplayName returns the name of x like the package level DisplayName, in
+// the language of l.
+func (l *Localizer) DisplayName(x interface{}) string {
+	return displayName(l.tag(), x)
+}
+
+// AvailableLocales lists the locales for which the message source has
+// translations, sorted by locale and named in their own language, e.g. to
+// fill a language picker. With several layers, it lists the locales of every
+// layer which can be enumerated. It fails if none can be, such as a ReadFunc
+// passed to SetMessagesFunc.
+func AvailableLocales() ([]AvailableLocale, error) {
+	files, err := listLayers()
+	if err != nil {
+		return nil, err
+	}
+	var locales []AvailableLocale
+	for _, file := range files {
+		locale := strings.TrimSuffix(file, ".json")
+		if matched, _ := regexp.MatchString(localeRegexp, locale); locale == file || !matched {
+			continue
+		}
+		locale = strings.Replace(locale, "_", "-", -1)
+		tag := language.Make(locale)
+		locales = append(locales, AvailableLocale{Locale: locale, Name: capitalize(displayName(tag, tag))})
+	}
+	sort.Slice(locales, func(i, j int) bool { return locales[i].Locale < locales[j].Locale })
+	return locales, nil
+}
+
+func displayName(in language.Tag, x interface{}) string {
+	switch v := x.(type) {
+	case string:
+		tag, err := language.Parse(strings.Replace(v, "_", "-", -1))
+		if err != nil {
+			return v
+		}
+		return tagDisplayName(in, tag)
+	case language.Tag:
+		return tagDisplayName(in, v)
+	case language.Base:
+		return namerName(display.Languages, in, v, v.String())
+	case language.Script:
+		return namerName(display.Scripts, in, v, v.String())
+	case language.Region:
+		return namerName(display.Regions, in, v, v.String())
+	case currency.Unit:
+		return currencyName(in, v.String())
+	default:
+		return fmt.Sprint(x)
+	}
+}
+
+// tagDisplayName names a locale by its language, followed by its script and
+// region if it has them.
+func tagDisplayName(in language.Tag, tag language.Tag) string {
+	base, _ := tag.Base()
+	name := namerName(display.Languages, in, base, base.String())
+	var qualifiers []string
+	if script, conf := tag.Script(); conf == language.Exact {
+		qualifiers = append(qualifiers, scriptDisplayName(in, script))
+	}
+	if region, conf := tag.Region(); conf == language.Exact {
+		qualifiers = append(qualifiers, namerName(display.Regions, in, region, region.String()))
+	}
+	if len(qualifiers) == 0 {
+		return name
+	}
+	inBase, _ := in.Base()
+	patterns, found := localeDisplayPatterns[inBase.String()]
+	if !found {
+		patterns = localePatterns{"{0} ({1})", "{0}, {1}"}
+	}
+	joined := qualifiers[0]
+	for _, q := range qualifiers[1:] {
+		joined = strings.NewReplacer("{0}", joined, "{1}", q).Replace(patterns.separator)
+	}
+	return strings.NewReplacer("{0}", name, "{1}", joined).Replace(patterns.pattern)
+}
+
+func scriptDisplayName(in language.Tag, script language.Script) string {
+	base, _ := in.Base()
+	if name, found := scriptDisplayNames[base.String()][script.String()]; found {
+		return name
+	}
+	return namerName(display.Scripts, in, script, script.String())
+}
+
+// namerName names x with the x/text namer for the language of in, falling
+// back to English and then to code.
+func namerName(namer func(language.Tag) display.Namer, in language.Tag, x interface{}, code string) string {
+	if name := namer(displayLanguage(in)).Name(x); name != "" {
+		return name
+	}
+	if name := namer(language.English).Name(x); name != "" {
+		return name
+	}
+	return code
+}
+
+// displayLanguage returns the language supported by x/text display closest
+// to in, or English.
+func displayLanguage(in language.Tag) language.Tag {
+	_, i, conf := displayMatcher.Match(in)
+	if conf == language.No {
+		return language.English
+	}
+	return displayTags[i]
+}
+
+func currencyName(in language.Tag, code string) string {
+	base, _ := in.Base()
+	if name, found := currencyNames[base.String()][code]; found {
+		return name
+	}
+	if name, found := currencyNames["en"][code]; found {
+		return name
+	}
+	return code
+}
+
+// capitalize upper cases the first letter of s, as CLDR does for names in
+// menus of languages such as French, which are otherwise lower case.
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToTitle(r)) + s[size:]
+}
@@ -0,0 +1,92 @@
+package i18n
+
+import (
+	"archive/tar"
+	"bytes"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+)
+
+func TestDisplayName(t *testing.T) {
+	for _, c := range []struct {
+		x        interface{}
+		inLocale string
+		expected string
+	}{
+		{"de", "de", "Deutsch"},
+		{"de", "en_US", "German"},
+		{"zh-Hans", "zh", "中文（简体）"},
+		{"zh-Hant-TW", "zh_CN", "中文（繁体，台湾）"},
+		{"en_US", "en", "English (United States)"},
+		{"en-US", "de", "Englisch (Vereinigte Staaten)"},
+		{"pt-BR", "ja", "ポルトガル語 (ブラジル)"},
+		{language.MustParseRegion("JP"), "fr", "Japon"},
+		{language.MustParseScript("Cyrl"), "en", "Cyrillic"},
+		{language.MustParseBase("ru"), "ru", "русский"},
+		{currency.EUR, "de", "Euro"},
+		{currency.USD, "ru", "доллар США"},
+		{currency.USD, "tr", "US Dollar"},
+		{currency.MustParseISO("XAF"), "en", "XAF"},
+		{"fr", "xx", "French"},
+		{"not a locale", "en", "not a locale"},
+	} {
+		assert.Equal(t, c.expected, DisplayName(c.x, c.inLocale), "%v in %v", c.x, c.inLocale)
+	}
+	l := &Localizer{locale: "es"}
+	assert.Equal(t, "inglés (Estados Unidos)", l.DisplayName("en-US"))
+}
+
+func TestAvailableLocales(t *testing.T) {
+	defer SetMessagesDir("locale")
+
+	SetMessagesDir("locale")
+	locales, err := AvailableLocales()
+	if assert.NoError(t, err) {
+		assert.Equal(t, []AvailableLocale{
+			{"en", "English"},
+			{"en-US", "English (United States)"},
+			{"zh", "中文"},
+			{"zh-CN", "中文（中国）"},
+		}, locales)
+	}
+
+	SetMessagesFS(fstest.MapFS{
+		"fr.json":    {Data: []byte(`{"HELLO": "Bonjour"}`)},
+		"pt_BR.json": {Data: []byte(`{"HELLO": "Olá"}`)},
+		"README.md":  {Data: []byte("not a locale")},
+	})
+	locales, err = AvailableLocales()
+	if assert.NoError(t, err) {
+		assert.Equal(t, []AvailableLocale{{"fr", "Français"}, {"pt-BR", "Português (Brasil)"}}, locales)
+	}
+	l, err := NewLocalizer("fr")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Bonjour", l.T("HELLO"))
+	}
+
+	var archive bytes.Buffer
+	w := tar.NewWriter(&archive)
+	body := []byte(`{"HELLO": "Hallo"}`)
+	assert.NoError(t, w.WriteHeader(&tar.Header{Name: "de.json", Mode: 0644, Size: int64(len(body)), Typeflag: tar.TypeReg}))
+	_, _ = w.Write(body)
+	assert.NoError(t, w.Close())
+	if assert.NoError(t, SetMessagesTar(archive.Bytes())) {
+		locales, err = AvailableLocales()
+		if assert.NoError(t, err) {
+			assert.Equal(t, []AvailableLocale{{"de", "Deutsch"}}, locales)
+		}
+		l, err = NewLocalizer("de")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "Hallo", l.T("HELLO"))
+		}
+	}
+	assert.Error(t, SetMessagesTar([]byte("not a tar archive")))
+
+	SetMessagesFunc(func(path string) ([]byte, error) { return nil, nil })
+	_, err = AvailableLocales()
+	assert.Error(t, err)
+}
@@ -0,0 +1,95 @@
+package i18n
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// FileError records a translation file which exists but couldn't be read or
+// decoded.
+type FileError struct {
+	// Layer is the name of the Layer of the file, empty for a single message
+	// source
+	Layer string
+	// File is the name of the file as passed to the ReadFunc
+	File string
+	// Offset is the number of bytes of the file read before decoding failed,
+	// as reported by encoding/json, or -1 if the file couldn't be read or the
+	// error has no position.
+	Offset int64
+	// Line and Column locate the last byte read in the file, starting at 1.
+	// They're zero if Offset is -1.
+	Line   int
+	Column int
+	Err    error
+}
+
+func (e *FileError) Error() string {
+	file := Layer{Name: e.Layer}.origin(e.File)
+	if e.Offset < 0 {
+		return fmt.Sprintf("%s: %v", file, e.Err)
+	}
+	return fmt.Sprintf("%s:%d:%d (offset %d): %v", file, e.Line, e.Column, e.Offset, e.Err)
+}
+
+func (e *FileError) Unwrap() error {
+	return e.Err
+}
+
+// originError builds a FileError for err, found in the file of the given
+// origin, as returned by Layer.origin.
+func originError(origin string, err error) *FileError {
+	fe := &FileError{File: origin, Offset: -1, Err: err}
+	if i := strings.LastIndex(origin, ":"); i >= 0 {
+		fe.Layer, fe.File = origin[:i], origin[i+1:]
+	}
+	return fe
+}
+
+// newDecodeError builds a FileError for a failure to decode buf, locating
+// JSON syntax and type errors in the file.
+func newDecodeError(fileName string, buf []byte, err error) *FileError {
+	fe := &FileError{File: fileName, Offset: -1, Err: err}
+	switch e := err.(type) {
+	case *json.SyntaxError:
+		fe.Offset = e.Offset
+	case *json.UnmarshalTypeError:
+		fe.Offset = e.Offset
+	}
+	if fe.Offset > 0 && fe.Offset <= int64(len(buf)) {
+		before := buf[:fe.Offset-1]
+		fe.Line = bytes.Count(before, []byte("\n")) + 1
+		fe.Column = len(before) - bytes.LastIndexByte(before, '\n')
+	}
+	return fe
+}
+
+// LoadError is returned by SetLocale and LoadLocale when some translation
+// files of a locale failed to load. It lists every file which failed, in the
+// order they were loaded, followed by the files supplying translations which
+// refer to undefined glossary terms, then those supplying translations longer
+// than the MaxLength of their key. The latter alone don't keep the locale from
+// loading, unless LoadOptions.StrictLength.
+type LoadError struct {
+	Locale string
+	Files  []*FileError
+}
+
+func (e *LoadError) Error() string {
+	msgs := make([]string, len(e.Files))
+	for i, f := range e.Files {
+		msgs[i] = f.Error()
+	}
+	return fmt.Sprintf("Error loading locale %s: %s", e.Locale, strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the error of each file which failed.
+func (e *LoadError) Unwrap() []error {
+	errs := make([]error, len(e.Files))
+	for i, f := range e.Files {
+		errs[i] = f
+	}
+	return errs
+}
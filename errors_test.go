@@ -0,0 +1,80 @@
+package i18n
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadError(t *testing.T) {
+	defer restoreState()()
+	SetMessagesFunc(func(path string) ([]byte, error) {
+		switch path {
+		case "en.json":
+			return []byte(`{"HELLO": "Hello %s!"}`), nil
+		case "zh.json":
+			return []byte("{\n  \"HELLO\": \"%s你好!\",\n  \"ONLY_IN_ZH\": \"I speak Chinese!\"\n}"), nil
+		case "zh-CN.json":
+			return []byte("{\n  \"ONLY_IN_ZH_CN\": \"I speak Mandarin!\"\n}}"), nil
+		case "zh-TW.json":
+			return []byte(`{"ONLY_IN_ZH_TW": 1}`), nil
+		case "fr.json":
+			return nil, errors.New("permission denied")
+		}
+		return nil, os.ErrNotExist
+	})
+
+	if !assert.NoError(t, setLocale("en_US")) {
+		return
+	}
+	_, err := SetLocale("zh_CN")
+	var loadErr *LoadError
+	if assert.True(t, errors.As(err, &loadErr), "should return a *LoadError") {
+		assert.Equal(t, "zh-CN", loadErr.Locale)
+		if assert.Len(t, loadErr.Files, 1) {
+			f := loadErr.Files[0]
+			assert.Equal(t, "zh-CN.json", f.File)
+			assert.EqualValues(t, 43, f.Offset)
+			assert.Equal(t, 3, f.Line)
+			assert.Equal(t, 2, f.Column)
+		}
+	}
+	assertTranslation(t, "[ONLY_IN_ZH]", "ONLY_IN_ZH")
+
+	_, err = LoadLocale("zh_TW", LoadOptions{})
+	if assert.True(t, errors.As(err, &loadErr), "should return a *LoadError on type errors") {
+		assert.Equal(t, "zh-TW.json", loadErr.Files[0].File)
+		assert.Equal(t, 1, loadErr.Files[0].Line)
+	}
+	assertTranslation(t, "[ONLY_IN_ZH]", "ONLY_IN_ZH")
+
+	locale, err := LoadLocale("zh_CN", LoadOptions{AllowPartial: true})
+	assert.Equal(t, "zh-CN", locale, "should set a partially loaded locale if allowed")
+	assert.Error(t, err, "should still return the error")
+	assertTranslation(t, "I speak Chinese!", "ONLY_IN_ZH")
+	assertTranslation(t, "[ONLY_IN_ZH_CN]", "ONLY_IN_ZH_CN")
+
+	locale, err = SetLocale("fr")
+	assert.NoError(t, err, "should take errors of a ReadFunc for missing files by default")
+	assert.Equal(t, "fr", locale)
+	_, err = LoadLocale("fr", LoadOptions{StrictRead: true})
+	if assert.True(t, errors.As(err, &loadErr), "should return a *LoadError on read errors") {
+		assert.EqualValues(t, -1, loadErr.Files[0].Offset)
+		assert.EqualError(t, loadErr, "Error loading locale fr: fr.json: permission denied")
+	}
+}
+
+// restoreState saves the global state modified by tests and returns a func
+// restoring it.
+func restoreState() func() {
+	trMutex.RLock()
+	l, ls, g := current, layers, buildGlossary
+	trMutex.RUnlock()
+	return func() {
+		trMutex.Lock()
+		defer trMutex.Unlock()
+		current, layers, buildGlossary = l, ls, g
+	}
+}
@@ -0,0 +1,95 @@
+package i18n
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// glossaryRegexp matches the references to glossary terms in messages, such
+// as {{APP_NAME}}. Names are upper case so as not to be mistaken for a
+// placeholder in a branch of a plural, as in "one {{name}}".
+var glossaryRegexp = regexp.MustCompile(`\{\{([A-Z][A-Z0-9_]*)\}\}`)
+
+// the glossary set through SetGlossary
+var buildGlossary map[string]string
+
+// SetGlossary defines glossary terms for every locale, e.g. the product name
+// and support URL of a build. Messages refer to them as {{APP_NAME}}, and the
+// references are replaced by the terms when translations are loaded, so the
+// glossary must be set before SetLocale or NewLocalizer. Translation files
+// define the terms of their locale as keys such as "{{APP_NAME}}", overriding
+// those of SetGlossary and following the fallback chain like messages. A
+// reference to a term which is undefined fails the loading of the locale.
+// Terms are inserted as is, without expanding their own references.
+func SetGlossary(terms map[string]string) {
+	g := make(map[string]string, len(terms))
+	for k, v := range terms {
+		g[k] = v
+	}
+	trMutex.Lock()
+	defer trMutex.Unlock()
+	buildGlossary = g
+}
+
+// GlossaryKey returns the key defining the glossary term name in translation
+// files, e.g. "{{APP_NAME}}" for APP_NAME.
+func GlossaryKey(name string) string {
+	return "{{" + name + "}}"
+}
+
+// SplitGlossaryKey returns the name of the glossary term defined by key, and
+// false if key isn't a GlossaryKey but a message.
+func SplitGlossaryKey(key string) (string, bool) {
+	m := glossaryRegexp.FindStringSubmatch(key)
+	if m == nil || m[0] != key {
+		return "", false
+	}
+	return m[1], true
+}
+
+// extractGlossary removes the glossary terms from the merged translations of
+// a Localizer, along with their origins, and returns them over the glossary
+// of SetGlossary.
+func extractGlossary(messages map[string]string, origins map[string]string) map[string]string {
+	trMutex.RLock()
+	terms := make(map[string]string, len(buildGlossary))
+	for k, v := range buildGlossary {
+		terms[k] = v
+	}
+	trMutex.RUnlock()
+	for k, v := range messages {
+		key, r := splitRegisterKey(k)
+		name, ok := SplitGlossaryKey(key)
+		if !ok {
+			continue
+		}
+		// terms have no variants
+		if r == RegisterNeutral {
+			terms[name] = v
+		}
+		delete(messages, k)
+		delete(origins, k)
+	}
+	return terms
+}
+
+// expandGlossary replaces the references to glossary terms in s with the
+// terms. It fails on the first reference to an undefined term.
+func expandGlossary(s string, terms map[string]string) (string, error) {
+	var undefined string
+	expanded := glossaryRegexp.ReplaceAllStringFunc(s, func(ref string) string {
+		name := ref[2 : len(ref)-2]
+		term, found := terms[name]
+		if !found {
+			if undefined == "" {
+				undefined = ref
+			}
+			return ref
+		}
+		return term
+	})
+	if undefined != "" {
+		return s, fmt.Errorf("undefined glossary term %s", undefined)
+	}
+	return expanded, nil
+}
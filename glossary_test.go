@@ -0,0 +1,60 @@
+package i18n
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGlossary(t *testing.T) {
+	defer restoreState()()
+	files := map[string]string{
+		"en.json":    `{"{{APP_NAME}}": "Lantern", "WELCOME": "Welcome to {{APP_NAME}}, {0}", "HELP": {"value": "Visit {{SUPPORT_URL}}", "maxLength": 40}, "FILES": "{n, plural, one {{name}} other {# files}}"}`,
+		"zh.json":    `{"{{APP_NAME}}": "蓝灯", "WELCOME": "欢迎使用{{APP_NAME}}, {0}"}`,
+		"zh-TW.json": `{"WELCOME": "歡迎使用{{APP_NAME}}, {0}"}`,
+		"fr.json":    `{"WELCOME": "Bienvenue dans {{APP_NOM}}"}`,
+	}
+	SetMessagesFunc(func(path string) ([]byte, error) {
+		if s, found := files[path]; found {
+			return []byte(s), nil
+		}
+		return nil, os.ErrNotExist
+	})
+	SetGlossary(map[string]string{"APP_NAME": "Acme", "SUPPORT_URL": "https://acme.example/help"})
+
+	l, err := NewLocalizer("en")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "Welcome to Lantern, Eva", l.T("WELCOME", "Eva"), "should prefer the terms of translation files")
+	assert.Equal(t, "Visit https://acme.example/help", l.T("HELP"))
+	assert.Equal(t, "Eva", l.T("FILES", Params{"n": 1, "name": "Eva"}), "shouldn't mistake placeholders for terms")
+	assert.Equal(t, "[{{APP_NAME}}]", l.T("{{APP_NAME}}"), "terms aren't messages")
+	_, found := l.Origin("{{APP_NAME}}")
+	assert.False(t, found)
+
+	l, err = NewLocalizer("zh-TW")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "歡迎使用蓝灯, 伊娃", l.T("WELCOME", "伊娃"), "should follow the fallback chain")
+	}
+
+	_, err = NewLocalizer("fr")
+	var loadErr *LoadError
+	if assert.True(t, errors.As(err, &loadErr)) && assert.Len(t, loadErr.Files, 1) {
+		assert.EqualError(t, loadErr.Files[0], "fr.json: WELCOME: undefined glossary term {{APP_NOM}}")
+	}
+
+	SetGlossary(map[string]string{"SUPPORT_URL": "https://example.com/a/very/long/help/page"})
+	_, err = NewLocalizer("en")
+	if assert.True(t, errors.As(err, &loadErr)) && assert.Len(t, loadErr.Files, 1) {
+		assert.EqualError(t, loadErr.Files[0], "en.json: HELP: 47 characters, longer than the maximum of 40", "should check the length of expanded messages")
+	}
+
+	name, ok := SplitGlossaryKey(GlossaryKey("APP_NAME"))
+	assert.True(t, ok)
+	assert.Equal(t, "APP_NAME", name)
+	_, ok = SplitGlossaryKey("Welcome to {{APP_NAME}}")
+	assert.False(t, ok)
+}
@@ -0,0 +1,220 @@
+package i18n
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Layer is a message source, which SetMessageLayers stacks over others, e.g.
+// a directory of per-customer overrides over embedded defaults.
+type Layer struct {
+	// Name identifies the layer in the origin of translations, e.g.
+	// "customer" for "customer:zh-CN.json". It may be empty if there's a
+	// single layer.
+	Name string
+	Read ReadFunc
+	// List returns the names of the files of the layer, and is nil if they
+	// can't be enumerated
+	List ListFunc
+	// set for a ReadFunc whose errors may mean that a file doesn't exist,
+	// see LoadOptions.StrictRead
+	lenient bool
+}
+
+// the message sources, from the lowest precedence to the highest
+var layers = []Layer{DirLayer("", "locale")}
+
+// SetMessageLayers tells i18n to read translations from several message
+// sources, each file of a later layer overriding the translations of the same
+// locale in earlier layers, key by key. The fallback chain still takes
+// precedence, so zh-CN.json of any layer overrides zh.json of every layer.
+// The origin of each translation, as reported by Origin and DebugHandler, is
+// the name of its layer and file.
+func SetMessageLayers(l ...Layer) {
+	trMutex.Lock()
+	defer trMutex.Unlock()
+	layers = append([]Layer(nil), l...)
+}
+
+// currentLayers returns a copy of the layers of SetMessageLayers, which can be
+// read without holding trMutex.
+func currentLayers() []Layer {
+	trMutex.RLock()
+	defer trMutex.RUnlock()
+	return append([]Layer(nil), layers...)
+}
+
+// DirLayer returns a Layer reading translations from the directory d.
+func DirLayer(name string, d string) Layer {
+	return Layer{Name: name, Read: makeReadFunc(d), List: makeListFunc(d)}
+}
+
+// FSLayer returns a Layer reading translations from the root of fsys, e.g. an
+// embed.FS.
+func FSLayer(name string, fsys fs.FS) Layer {
+	return Layer{
+		Name: name,
+		Read: func(p string) ([]byte, error) {
+			return fs.ReadFile(fsys, p)
+		},
+		List: func() ([]string, error) {
+			return listFS(fsys)
+		},
+	}
+}
+
+// FuncLayer returns a Layer reading translations through read. Its files
+// can't be enumerated by AvailableLocales, and its errors mean that a file
+// doesn't exist unless LoadOptions.StrictRead is set.
+func FuncLayer(name string, read ReadFunc) Layer {
+	return Layer{Name: name, Read: read, lenient: true}
+}
+
+// TarLayer returns a Layer reading translations from a tar archive, such as
+// locale.Resources.
+func TarLayer(name string, archive []byte) (Layer, error) {
+	files := make(map[string][]byte)
+	r := tar.NewReader(bytes.NewReader(archive))
+	for {
+		hdr, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return Layer{}, fmt.Errorf("Error read tar archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		buf, err := ioutil.ReadAll(r)
+		if err != nil {
+			return Layer{}, fmt.Errorf("Error read %s in tar archive: %w", hdr.Name, err)
+		}
+		files[path.Clean(hdr.Name)] = buf
+	}
+	read := func(p string) ([]byte, error) {
+		buf, found := files[p]
+		if !found {
+			return nil, fmt.Errorf("%s not in tar archive: %w", p, os.ErrNotExist)
+		}
+		return buf, nil
+	}
+	list := func() ([]string, error) {
+		names := make([]string, 0, len(files))
+		for name := range files {
+			names = append(names, name)
+		}
+		return names, nil
+	}
+	return Layer{Name: name, Read: read, List: list}, nil
+}
+
+// origin returns the origin of the translations of fileName in the layer.
+func (l Layer) origin(fileName string) string {
+	if l.Name == "" {
+		return fileName
+	}
+	return l.Name + ":" + fileName
+}
+
+// originLocale returns the locale of the file of origin, e.g. "zh" for
+// "customer:zh.json".
+func originLocale(origin string) string {
+	return strings.TrimSuffix(origin[strings.LastIndex(origin, ":")+1:], ".json")
+}
+
+// listLayers returns the names of the files of every layer which can be
+// enumerated, sorted and without duplicates. It fails if no layer can be.
+func listLayers() ([]string, error) {
+	seen := make(map[string]bool)
+	listed := false
+	for _, l := range currentLayers() {
+		if l.List == nil {
+			continue
+		}
+		listed = true
+		files, err := l.List()
+		if err != nil {
+			return nil, fmt.Errorf("Unable to list the files of the message source: %w", err)
+		}
+		for _, f := range files {
+			seen[f] = true
+		}
+	}
+	if !listed {
+		return nil, errors.New("Unable to list the files of the message source")
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// loadLocale loads the translations of locale from every layer, returning a
+// nil catalog if no layer has a file for it. origins records the origin of
+// each key of the merged catalog, including the variants keyed by
+// registerKey, and files lists the origins of the files loaded, from the
+// lowest precedence to the highest. strict is LoadOptions.StrictRead.
+func loadLocale(locale string, strict bool) (c *Catalog, origins map[string]string, files []string, errs []*FileError) {
+	for _, l := range currentLayers() {
+		lc, err := loadCatalogFile(l, locale, strict)
+		if err != nil {
+			log.Debugf("Locale %s not loaded: %s", locale, err)
+			errs = append(errs, err)
+			continue
+		}
+		if lc == nil {
+			continue
+		}
+		origin := l.origin(locale + ".json")
+		if c == nil {
+			c = &Catalog{Locale: locale, Messages: make(map[string]string)}
+			origins = make(map[string]string)
+		}
+		c.merge(lc)
+		for k := range lc.merged() {
+			origins[k] = origin
+		}
+		files = append(files, origin)
+	}
+	if c == nil {
+		log.Tracef("Locale %s not found", locale)
+	}
+	return c, origins, files, errs
+}
+
+// merge merges the translations of o into c, those of o taking precedence
+// key by key. A key of o replaces the variants of c, so those it doesn't
+// supply fall back to its value.
+func (c *Catalog) merge(o *Catalog) {
+	for k, v := range o.Messages {
+		c.Messages[k] = v
+		delete(c.Variants, k)
+	}
+	for k, info := range o.Info {
+		if c.Info == nil {
+			c.Info = make(map[string]MessageInfo)
+		}
+		c.Info[k] = info
+	}
+	for k, variants := range o.Variants {
+		if c.Variants == nil {
+			c.Variants = make(map[string]map[Register]string)
+		}
+		c.Variants[k] = make(map[Register]string, len(variants))
+		for r, v := range variants {
+			c.Variants[k][r] = v
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package i18n
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLayers(t *testing.T) {
+	defer restoreState()()
+	defaults := fstest.MapFS{
+		"en.json": {Data: []byte(`{"TITLE": "Acme", "SAVE": "Save", "HELP": {"value": "Help", "maxLength": 8}}`)},
+		"de.json": {Data: []byte(`{"TITLE": "Acme", "SAVE": "Speichern", "HELP": {"value": "Hilfe", "informal": "Hilf mir"}}`)},
+	}
+	product := fstest.MapFS{
+		"en.json":    {Data: []byte(`{"TITLE": "Acme Mail"}`)},
+		"de-AT.json": {Data: []byte(`{"SAVE": "Sichern"}`)},
+	}
+	overrides := map[string]string{
+		"de.json": `{"TITLE": {"value": "Kunde Mail", "formal": "Kunde Mail für Sie"}, "HELP": "Hilfe und Unterstützung"}`,
+	}
+	SetMessageLayers(
+		FSLayer("defaults", defaults),
+		FSLayer("product", product),
+		FuncLayer("customer", func(path string) ([]byte, error) {
+			if s, found := overrides[path]; found {
+				return []byte(s), nil
+			}
+			return nil, os.ErrNotExist
+		}),
+	)
+
+	l, err := NewLocalizer("de-AT")
+	var loadErr *LoadError
+	if assert.True(t, errors.As(err, &loadErr)) && assert.Len(t, loadErr.Files, 1) {
+		assert.Equal(t, "customer", loadErr.Files[0].Layer)
+		assert.EqualError(t, loadErr.Files[0], "customer:de.json: HELP: 23 characters, longer than the maximum of 8")
+	}
+	l, err = LoadLocalizer("de-AT", LoadOptions{AllowPartial: true})
+	if !assert.Error(t, err) {
+		return
+	}
+	assert.Equal(t, "Kunde Mail", l.T("TITLE"), "should override earlier layers")
+	assert.Equal(t, "Kunde Mail für Sie", l.WithRegister(RegisterFormal).T("TITLE"))
+	assert.Equal(t, "Hilfe und Unterstützung", l.WithRegister(RegisterInformal).T("HELP"), "should drop the variants of a key overridden by a later layer")
+	assert.Equal(t, "Sichern", l.T("SAVE"), "should prefer the locale to later layers")
+	assert.Equal(t, []string{"defaults:en.json", "product:en.json", "defaults:de.json", "customer:de.json", "product:de-AT.json"}, l.files)
+	origin, found := l.Origin("TITLE")
+	assert.True(t, found)
+	assert.Equal(t, "customer:de.json", origin)
+	origin, _ = l.Origin("SAVE")
+	assert.Equal(t, "product:de-AT.json", origin)
+	origin, _ = l.Origin(registerKey("TITLE", RegisterFormal))
+	assert.Equal(t, "customer:de.json", origin)
+	_, found = l.Origin("MISSING")
+	assert.False(t, found)
+
+	c, err := LoadCatalog("en")
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]string{"TITLE": "Acme Mail", "SAVE": "Save", "HELP": "Help"}, c.Messages, "should merge the layers")
+		assert.Equal(t, 8, c.Info["HELP"].MaxLength)
+	}
+	c, err = LoadCatalog("de")
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]map[Register]string{"TITLE": {RegisterFormal: "Kunde Mail für Sie"}}, c.Variants)
+	}
+	stats, err := Stats("de-AT")
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]int{"de-AT": 1, "de": 2}, stats.ByLevel)
+	}
+
+	locales, err := AvailableLocales()
+	if assert.NoError(t, err) {
+		assert.Len(t, locales, 3, "should list the locales of the layers which can be enumerated")
+	}
+	SetMessageLayers(FuncLayer("customer", func(path string) ([]byte, error) { return nil, os.ErrNotExist }))
+	_, err = AvailableLocales()
+	assert.Error(t, err, "should fail if no layer can be enumerated")
+}
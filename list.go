@@ -0,0 +1,272 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/language"
+)
+
+// ListStyle selects how FormatList joins items.
+type ListStyle int
+
+const (
+	// ListAnd joins items as a conjunction, e.g. "A, B, and C".
+	ListAnd ListStyle = iota
+	// ListOr joins items as a disjunction, e.g. "A, B, or C".
+	ListOr
+	// ListUnit joins quantities with units, e.g. "3 feet, 7 inches".
+	ListUnit
+)
+
+// List is a list of items, which is joined following the conventions of the
+// locale when passed to a message, e.g. {names} or {names, list, or}.
+type List struct {
+	Items []string
+	Style ListStyle
+}
+
+// listPatterns are the CLDR patterns joining two items, the first two of a
+// longer list, the middle ones and the last two, where {0} and {1} stand for
+// the items or the list joined so far.
+type listPatterns struct {
+	two, start, middle, end string
+}
+
+var listLanguages = map[string]map[ListStyle]listPatterns{
+	"ar": {
+		ListAnd:  {"{0} و{1}", "{0} و{1}", "{0} و{1}", "{0} و{1}"},
+		ListOr:   {"{0} أو {1}", "{0} أو {1}", "{0} أو {1}", "{0} أو {1}"},
+		ListUnit: {"{0} و{1}", "{0} و{1}", "{0} و{1}", "{0} و{1}"},
+	},
+	"cs": {
+		ListAnd:  {"{0} a {1}", "{0}, {1}", "{0}, {1}", "{0} a {1}"},
+		ListOr:   {"{0} nebo {1}", "{0}, {1}", "{0}, {1}", "{0} nebo {1}"},
+		ListUnit: {"{0} a {1}", "{0}, {1}", "{0}, {1}", "{0} a {1}"},
+	},
+	"da": {
+		ListAnd:  {"{0} og {1}", "{0}, {1}", "{0}, {1}", "{0} og {1}"},
+		ListOr:   {"{0} eller {1}", "{0}, {1}", "{0}, {1}", "{0} eller {1}"},
+		ListUnit: {"{0} og {1}", "{0}, {1}", "{0}, {1}", "{0} og {1}"},
+	},
+	"de": {
+		ListAnd:  {"{0} und {1}", "{0}, {1}", "{0}, {1}", "{0} und {1}"},
+		ListOr:   {"{0} oder {1}", "{0}, {1}", "{0}, {1}", "{0} oder {1}"},
+		ListUnit: {"{0}, {1}", "{0}, {1}", "{0}, {1}", "{0} und {1}"},
+	},
+	"en": {
+		ListAnd:  {"{0} and {1}", "{0}, {1}", "{0}, {1}", "{0}, and {1}"},
+		ListOr:   {"{0} or {1}", "{0}, {1}", "{0}, {1}", "{0}, or {1}"},
+		ListUnit: {"{0}, {1}", "{0}, {1}", "{0}, {1}", "{0}, {1}"},
+	},
+	"en-GB": {
+		ListAnd:  {"{0} and {1}", "{0}, {1}", "{0}, {1}", "{0} and {1}"},
+		ListOr:   {"{0} or {1}", "{0}, {1}", "{0}, {1}", "{0} or {1}"},
+		ListUnit: {"{0}, {1}", "{0}, {1}", "{0}, {1}", "{0}, {1}"},
+	},
+	"es": {
+		ListAnd:  {"{0} y {1}", "{0}, {1}", "{0}, {1}", "{0} y {1}"},
+		ListOr:   {"{0} o {1}", "{0}, {1}", "{0}, {1}", "{0} o {1}"},
+		ListUnit: {"{0} y {1}", "{0}, {1}", "{0}, {1}", "{0} y {1}"},
+	},
+	"fa": {
+		ListAnd:  {"{0} و {1}", "{0}،‏ {1}", "{0}،‏ {1}", "{0}، و {1}"},
+		ListOr:   {"{0} یا {1}", "{0}،‏ {1}", "{0}،‏ {1}", "{0}، یا {1}"},
+		ListUnit: {"{0}، {1}", "{0}،‏ {1}", "{0}،‏ {1}", "{0}، و {1}"},
+	},
+	"fi": {
+		ListAnd:  {"{0} ja {1}", "{0}, {1}", "{0}, {1}", "{0} ja {1}"},
+		ListOr:   {"{0} tai {1}", "{0}, {1}", "{0}, {1}", "{0} tai {1}"},
+		ListUnit: {"{0} ja {1}", "{0}, {1}", "{0}, {1}", "{0} ja {1}"},
+	},
+	"fr": {
+		ListAnd:  {"{0} et {1}", "{0}, {1}", "{0}, {1}", "{0} et {1}"},
+		ListOr:   {"{0} ou {1}", "{0}, {1}", "{0}, {1}", "{0} ou {1}"},
+		ListUnit: {"{0} et {1}", "{0}, {1}", "{0}, {1}", "{0} et {1}"},
+	},
+	"he": {
+		ListAnd:  {"{0} ו{1}", "{0}, {1}", "{0}, {1}", "{0} ו{1}"},
+		ListOr:   {"{0} או {1}", "{0}, {1}", "{0}, {1}", "{0} או {1}"},
+		ListUnit: {"{0} ו{1}", "{0}, {1}", "{0}, {1}", "{0} ו{1}"},
+	},
+	"hi": {
+		ListAnd:  {"{0} और {1}", "{0}, {1}", "{0}, {1}", "{0}, और {1}"},
+		ListOr:   {"{0} या {1}", "{0}, {1}", "{0}, {1}", "{0} या {1}"},
+		ListUnit: {"{0}, {1}", "{0}, {1}", "{0}, {1}", "{0}, और {1}"},
+	},
+	"it": {
+		ListAnd:  {"{0} e {1}", "{0}, {1}", "{0}, {1}", "{0} e {1}"},
+		ListOr:   {"{0} o {1}", "{0}, {1}", "{0}, {1}", "{0} o {1}"},
+		ListUnit: {"{0} e {1}", "{0}, {1}", "{0}, {1}", "{0} e {1}"},
+	},
+	"ja": {
+		ListAnd:  {"{0}、{1}", "{0}、{1}", "{0}、{1}", "{0}、{1}"},
+		ListOr:   {"{0}または{1}", "{0}、{1}", "{0}、{1}", "{0}、または{1}"},
+		ListUnit: {"{0} {1}", "{0} {1}", "{0} {1}", "{0} {1}"},
+	},
+	"ko": {
+		ListAnd:  {"{0} 및 {1}", "{0}, {1}", "{0}, {1}", "{0} 및 {1}"},
+		ListOr:   {"{0} 또는 {1}", "{0}, {1}", "{0}, {1}", "{0} 또는 {1}"},
+		ListUnit: {"{0} {1}", "{0} {1}", "{0} {1}", "{0} {1}"},
+	},
+	"nb": {
+		ListAnd:  {"{0} og {1}", "{0}, {1}", "{0}, {1}", "{0} og {1}"},
+		ListOr:   {"{0} eller {1}", "{0}, {1}", "{0}, {1}", "{0} eller {1}"},
+		ListUnit: {"{0}, {1}", "{0}, {1}", "{0}, {1}", "{0} og {1}"},
+	},
+	"nl": {
+		ListAnd:  {"{0} en {1}", "{0}, {1}", "{0}, {1}", "{0} en {1}"},
+		ListOr:   {"{0} of {1}", "{0}, {1}", "{0}, {1}", "{0} of {1}"},
+		ListUnit: {"{0} en {1}", "{0}, {1}", "{0}, {1}", "{0} en {1}"},
+	},
+	"pl": {
+		ListAnd:  {"{0} i {1}", "{0}, {1}", "{0}, {1}", "{0} i {1}"},
+		ListOr:   {"{0} lub {1}", "{0}, {1}", "{0}, {1}", "{0} lub {1}"},
+		ListUnit: {"{0} i {1}", "{0}, {1}", "{0}, {1}", "{0} i {1}"},
+	},
+	"pt": {
+		ListAnd:  {"{0} e {1}", "{0}, {1}", "{0}, {1}", "{0} e {1}"},
+		ListOr:   {"{0} ou {1}", "{0}, {1}", "{0}, {1}", "{0} ou {1}"},
+		ListUnit: {"{0} e {1}", "{0}, {1}", "{0}, {1}", "{0} e {1}"},
+	},
+	"ru": {
+		ListAnd:  {"{0} и {1}", "{0}, {1}", "{0}, {1}", "{0} и {1}"},
+		ListOr:   {"{0} или {1}", "{0}, {1}", "{0}, {1}", "{0} или {1}"},
+		ListUnit: {"{0} {1}", "{0} {1}", "{0} {1}", "{0} {1}"},
+	},
+	"sv": {
+		ListAnd:  {"{0} och {1}", "{0}, {1}", "{0}, {1}", "{0} och {1}"},
+		ListOr:   {"{0} eller {1}", "{0}, {1}", "{0}, {1}", "{0} eller {1}"},
+		ListUnit: {"{0} och {1}", "{0}, {1}", "{0}, {1}", "{0} och {1}"},
+	},
+	"tr": {
+		ListAnd:  {"{0} ve {1}", "{0}, {1}", "{0}, {1}", "{0} ve {1}"},
+		ListOr:   {"{0} veya {1}", "{0}, {1}", "{0}, {1}", "{0} veya {1}"},
+		ListUnit: {"{0}, {1}", "{0}, {1}", "{0}, {1}", "{0}, {1}"},
+	},
+	"uk": {
+		ListAnd:  {"{0} і {1}", "{0}, {1}", "{0}, {1}", "{0} і {1}"},
+		ListOr:   {"{0} або {1}", "{0}, {1}", "{0}, {1}", "{0} або {1}"},
+		ListUnit: {"{0}, {1}", "{0}, {1}", "{0}, {1}", "{0} і {1}"},
+	},
+	"vi": {
+		ListAnd:  {"{0} và {1}", "{0}, {1}", "{0}, {1}", "{0} và {1}"},
+		ListOr:   {"{0} hoặc {1}", "{0}, {1}", "{0}, {1}", "{0} hoặc {1}"},
+		ListUnit: {"{0}, {1}", "{0}, {1}", "{0}, {1}", "{0}, {1}"},
+	},
+	"zh": {
+		ListAnd:  {"{0}和{1}", "{0}、{1}", "{0}、{1}", "{0}和{1}"},
+		ListOr:   {"{0}或{1}", "{0}、{1}", "{0}、{1}", "{0}或{1}"},
+		ListUnit: {"{0}{1}", "{0}{1}", "{0}{1}", "{0}{1}"},
+	},
+}
+
+// FormatList joins items following the CLDR list patterns of the current
+// locale, e.g. "A, B, and C" in en-US, "A、B和C" in zh-CN and "A, B y C" in es
+// for ListAnd. Languages without list patterns are joined like English.
+func FormatList(items []string, style ListStyle) string {
+	return currentLocalizer().FormatList(items, style)
+}
+
+// FormatList joins items like the package level FormatList, in the locale of
+// l.
+func (l *Localizer) FormatList(items []string, style ListStyle) string {
+	return formatList(l.tag(), items, style)
+}
+
+func formatList(tag language.Tag, items []string, style ListStyle) string {
+	base, _ := tag.Base()
+	patterns := listPatternsFor(tag)[style]
+	join := func(pattern, first, second string) string {
+		if base.String() == "es" {
+			pattern = spanishConjunction(pattern, second)
+		}
+		return strings.NewReplacer("{0}", first, "{1}", second).Replace(pattern)
+	}
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	case 2:
+		return join(patterns.two, items[0], items[1])
+	}
+	n := len(items)
+	s := join(patterns.end, items[n-2], items[n-1])
+	for i := n - 3; i > 0; i-- {
+		s = join(patterns.middle, items[i], s)
+	}
+	return join(patterns.start, items[0], s)
+}
+
+// listPatternsFor returns the list patterns of the locale or language of tag,
+// or English if it has none.
+func listPatternsFor(tag language.Tag) map[ListStyle]listPatterns {
+	base, _ := tag.Base()
+	if region, conf := tag.Region(); conf == language.Exact {
+		if patterns, found := listLanguages[base.String()+"-"+region.String()]; found {
+			return patterns
+		}
+	}
+	if patterns, found := listLanguages[base.String()]; found {
+		return patterns
+	}
+	return listLanguages["en"]
+}
+
+// spanishConjunction replaces "y" by "e" before words sounding like "i", and
+// "o" by "u" before words sounding like "o", as CLDR does.
+func spanishConjunction(pattern string, next string) string {
+	word := strings.ToLower(next)
+	if strings.HasPrefix(word, "h") {
+		word = word[1:]
+	}
+	switch {
+	case strings.Contains(pattern, " y ") && (strings.HasPrefix(word, "i") || strings.HasPrefix(word, "í")) &&
+		!startsWithVowelAfter(word, "i"):
+		return strings.Replace(pattern, " y ", " e ", 1)
+	case strings.Contains(pattern, " o ") && (strings.HasPrefix(word, "o") || strings.HasPrefix(word, "ó") ||
+		strings.HasPrefix(next, "8") || strings.HasPrefix(next, "11 ") || next == "11"):
+		return strings.Replace(pattern, " o ", " u ", 1)
+	}
+	return pattern
+}
+
+// startsWithVowelAfter reports whether word continues with a vowel after
+// prefix, such as "ie" in "hielo", which sounds like "y".
+func startsWithVowelAfter(word string, prefix string) bool {
+	rest := strings.TrimPrefix(strings.TrimPrefix(word, prefix), "í")
+	for _, r := range rest {
+		return strings.ContainsRune("aeiouáéíóú", unicode.ToLower(r))
+	}
+	return false
+}
+
+// parseListStyle parses the style of a list placeholder.
+func parseListStyle(s string) (ListStyle, error) {
+	switch s {
+	case "", "and":
+		return ListAnd, nil
+	case "or":
+		return ListOr, nil
+	case "unit":
+		return ListUnit, nil
+	default:
+		return ListAnd, fmt.Errorf("unknown list style %q", s)
+	}
+}
+
+// formatListArg formats the argument of a list placeholder, which is a List
+// or a []string joined in the style of the placeholder.
+func formatListArg(tag language.Tag, v interface{}, s string) string {
+	switch v := v.(type) {
+	case List:
+		return formatList(tag, v.Items, v.Style)
+	case []string:
+		style, _ := parseListStyle(s)
+		return formatList(tag, v, style)
+	default:
+		return fmt.Sprint(v)
+	}
+}
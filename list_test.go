@@ -0,0 +1,61 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestFormatList(t *testing.T) {
+	abc := []string{"A", "B", "C"}
+	for _, c := range []struct {
+		locale   string
+		items    []string
+		style    ListStyle
+		expected string
+	}{
+		{"en-US", nil, ListAnd, ""},
+		{"en-US", []string{"A"}, ListAnd, "A"},
+		{"en-US", []string{"A", "B"}, ListAnd, "A and B"},
+		{"en-US", abc, ListAnd, "A, B, and C"},
+		{"en-US", []string{"A", "B", "C", "D"}, ListOr, "A, B, C, or D"},
+		{"en-US", []string{"3 feet", "7 inches"}, ListUnit, "3 feet, 7 inches"},
+		{"en-GB", abc, ListAnd, "A, B and C"},
+		{"zh-CN", abc, ListAnd, "A、B和C"},
+		{"es", abc, ListAnd, "A, B y C"},
+		{"es", []string{"Pablo", "Ignacio"}, ListAnd, "Pablo e Ignacio"},
+		{"es", []string{"agua", "hielo"}, ListAnd, "agua y hielo"},
+		{"es", []string{"siete", "ocho"}, ListOr, "siete u ocho"},
+		{"de", abc, ListOr, "A, B oder C"},
+		{"ja", abc, ListAnd, "A、B、C"},
+		{"nl", abc, ListAnd, "A, B en C"},
+		{"pl", abc, ListOr, "A, B lub C"},
+		{"he", abc, ListAnd, "A, B וC"},
+		{"xx", abc, ListAnd, "A, B, and C"},
+	} {
+		assert.Equal(t, c.expected, formatList(language.Make(c.locale), c.items, c.style), "%v in %v", c.items, c.locale)
+	}
+}
+
+func TestListsCoverLocales(t *testing.T) {
+	for _, locale := range coveredLocales(t) {
+		tag := language.Make(locale)
+		assert.NotEqual(t, "A, B, and C", formatList(tag, []string{"A", "B", "C"}, ListAnd), "%s should not fall back to English", locale)
+		assert.NotEqual(t, "A, B, or C", formatList(tag, []string{"A", "B", "C"}, ListOr), "%s should not fall back to English", locale)
+	}
+}
+
+func TestListPlaceholder(t *testing.T) {
+	l := &Localizer{locale: "fr", messages: map[string]string{
+		"SHARED": "Partagé avec {names}",
+		"CHOOSE": "Choisissez {0, list, or}",
+	}}
+	assert.Equal(t, "Partagé avec Anne, Paul et Marie", l.T("SHARED", Params{"names": []string{"Anne", "Paul", "Marie"}}))
+	assert.Equal(t, "Partagé avec Anne ou Paul", l.T("SHARED", Params{"names": List{Items: []string{"Anne", "Paul"}, Style: ListOr}}))
+	assert.Equal(t, "Choisissez A, B ou C", l.T("CHOOSE", []string{"A", "B", "C"}))
+	assert.Equal(t, "A, B et C", l.FormatList([]string{"A", "B", "C"}, ListAnd))
+
+	_, err := Placeholders("{0, list, xor}")
+	assert.Error(t, err)
+}
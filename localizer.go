@@ -0,0 +1,223 @@
+package i18n
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// Localizer translates messages and formats values for a single locale. Its
+// translations are loaded when it's created and never change afterwards, but
+// for its Overrides, so it's safe for concurrent use. The package level
+// functions such as T use the Localizer of the current locale, set through
+// SetLocale.
+type Localizer struct {
+	locale    string
+	fallbacks []string
+	// read from a nil map is ok, so the zero Localizer has no translations
+	messages map[string]string
+	// the origin of each message, see Origin
+	origins map[string]string
+	// the MessageInfo of the keys which have one
+	info map[string]MessageInfo
+	// the origins of the files loaded, from the lowest precedence to the
+	// highest
+	files []string
+	// the register of translations, see WithRegister
+	register Register
+	// nil unless made by WithOverrides
+	overrides *Overrides
+}
+
+// NewLocalizer loads the translations of the given locale, with the same
+// fallbacks and errors as SetLocale, without changing the current locale.
+func NewLocalizer(locale string) (*Localizer, error) {
+	return LoadLocalizer(locale, LoadOptions{})
+}
+
+// LoadLocalizer is like NewLocalizer, with opts deciding whether a partially
+// loaded locale is acceptable. If it is, the Localizer is returned along with
+// the *LoadError listing the files which failed.
+func LoadLocalizer(locale string, opts LoadOptions) (*Localizer, error) {
+	if matched, _ := regexp.MatchString(localeRegexp, locale); !matched {
+		return nil, fmt.Errorf("Malformated locale string %s", locale)
+	}
+	const sep = "-"
+	locale = strings.Replace(locale, "_", sep, -1)
+	l := &Localizer{
+		locale:    locale,
+		fallbacks: fallbackChain(locale),
+		messages:  make(map[string]string),
+		origins:   make(map[string]string),
+		info:      make(map[string]MessageInfo),
+	}
+	var loadErr *LoadError
+	addErr := func(err *FileError) {
+		if loadErr == nil {
+			loadErr = &LoadError{Locale: locale}
+		}
+		loadErr.Files = append(loadErr.Files, err)
+	}
+	for i := len(l.fallbacks) - 1; i >= 0; i-- {
+		files, errs := mergeLocaleToMap(l.messages, l.origins, l.info, l.fallbacks[i], opts)
+		l.files = append(l.files, files...)
+		for _, err := range errs {
+			addErr(err)
+		}
+	}
+	// messages are checked with the glossary terms expanded
+	terms := extractGlossary(l.messages, l.origins)
+	for _, k := range sortedKeys(l.messages) {
+		s, err := expandGlossary(l.messages[k], terms)
+		if err != nil {
+			addErr(originError(l.origins[k], fmt.Errorf("%s: %w", describeKey(k), err)))
+		}
+		l.messages[k] = s
+	}
+	// the MaxLength of a key usually comes from the file of another locale
+	var lengthErrs []*FileError
+	for _, k := range sortedKeys(l.messages) {
+		infoKey, _ := splitRegisterKey(k)
+		if err := checkLength(l.messages[k], l.info[infoKey]); err != nil {
+			lengthErrs = append(lengthErrs, originError(l.origins[k], fmt.Errorf("%s: %w", describeKey(k), err)))
+		}
+	}
+	if opts.StrictLength {
+		for _, err := range lengthErrs {
+			addErr(err)
+		}
+		lengthErrs = nil
+	}
+	if loadErr != nil && !opts.AllowPartial {
+		return nil, loadErr
+	}
+	if len(l.messages) == 0 {
+		if loadErr != nil {
+			return nil, loadErr
+		}
+		return nil, fmt.Errorf("Not found any translations, locale not set")
+	}
+	log.Tracef("Translations: %v", l.messages)
+	// too long messages are reported without failing the load
+	for _, err := range lengthErrs {
+		addErr(err)
+	}
+	if loadErr != nil {
+		return l, loadErr
+	}
+	return l, nil
+}
+
+// Locale returns the locale of l, e.g. "zh-CN".
+func (l *Localizer) Locale() string {
+	return l.locale
+}
+
+// Fallbacks returns the locales searched for translations, in order.
+func (l *Localizer) Fallbacks() []string {
+	return append([]string(nil), l.fallbacks...)
+}
+
+// Info returns the MessageInfo of key like the package level Info, from the
+// translation files of l.
+func (l *Localizer) Info(key string) (MessageInfo, bool) {
+	info, found := l.info[key]
+	return info, found
+}
+
+// Origin returns the origin of the translation of key like the package level
+// Origin, among the translation files of l.
+func (l *Localizer) Origin(key string) (string, bool) {
+	origin, found := l.origins[key]
+	return origin, found
+}
+
+// T translates the given key like the package level T, in the locale of l.
+func (l *Localizer) T(key string, args ...interface{}) string {
+	return l.translate(key, args, nil)
+}
+
+// translate translates key, rendering rich text messages with m unless it's
+// nil.
+func (l *Localizer) translate(key string, args []interface{}, m *markup) string {
+	trMutex.RLock()
+	handleMissing, native, isolation, register := missingHandler, nativeDigits, bidiIsolation, currentRegister
+	trMutex.RUnlock()
+	if l.register != RegisterDefault {
+		register = l.register
+	}
+	s, found := l.lookup(key, register)
+	if !found {
+		return m.text(handleMissing(key, l.locale, l.fallbacks))
+	}
+	args, mapping := caseMappingArg(args)
+	tag := localeTag(l.locale, native)
+	if m != nil {
+		// map the case of the text only, not of the markup
+		m.lang, m.mapping = tag, mapping
+		mapping = CaseNone
+		// text which isn't HTML has no bdi tags
+		if m.escape == nil && isolation == BidiHTML {
+			isolation = BidiControls
+		}
+	}
+
+	// Format string
+	if hasPlaceholders(s) || m != nil && !printfVerbRegexp.MatchString(s) {
+		msg, err := parseMessage(s)
+		if err == nil {
+			return mapCase(tag, msg.render(tag, args, isolation, m), mapping)
+		}
+		log.Debugf("Unable to parse message %s, formatting it with printf: %v", key, err)
+	} else if m != nil && len(args) > 0 {
+		// the tags of printf messages are rendered once they're formatted
+		msg, err := parseMessage(formatPrintfMarkup(tag, s, args, isolation))
+		if err == nil {
+			return mapCase(tag, msg.render(tag, nil, BidiNone, m.withBidiTag(isolation)), mapping)
+		}
+		log.Debugf("Unable to parse message %s, formatting it with printf: %v", key, err)
+	}
+	if s != "" && len(args) > 0 {
+		s = fmt.Sprintf(s, isolateArgs(tag, args, isolation)...)
+	}
+
+	return mapCase(tag, m.text(s), mapping)
+}
+
+// TC translates the given key like the package level TC, in the locale of l.
+func (l *Localizer) TC(context string, key string, args ...interface{}) string {
+	return l.translate(ContextKey(context, key), args, nil)
+}
+
+// TN translates the given key like the package level TN, in the locale of l.
+func (l *Localizer) TN(key string, n interface{}, args ...interface{}) string {
+	return l.T(key, append(append([]interface{}(nil), args...), Params{"count": n})...)
+}
+
+// tag returns the language tag used to format values in the locale of l.
+func (l *Localizer) tag() language.Tag {
+	trMutex.RLock()
+	native := nativeDigits
+	trMutex.RUnlock()
+	return localeTag(l.locale, native)
+}
+
+// FormatNumber formats n like the package level FormatNumber, in the locale
+// of l.
+func (l *Localizer) FormatNumber(n interface{}) string {
+	return formatNumber(l.tag(), n, "")
+}
+
+// FormatPercent formats n like the package level FormatPercent, in the locale
+// of l.
+func (l *Localizer) FormatPercent(n interface{}) string {
+	return formatNumber(l.tag(), n, "percent")
+}
+
+// FormatCompact formats n like the package level FormatCompact, in the locale
+// of l.
+func (l *Localizer) FormatCompact(n interface{}) string {
+	return formatNumber(l.tag(), n, "compact")
+}
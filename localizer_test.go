@@ -0,0 +1,34 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalizer(t *testing.T) {
+	defer restoreState()()
+	SetMessagesDir("locale")
+	if !assert.NoError(t, setLocale("en_US")) {
+		return
+	}
+	zh, err := NewLocalizer("zh_CN")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "zh-CN", zh.Locale())
+	assert.Equal(t, []string{"zh-CN", "zh", "en-US", "en"}, zh.Fallbacks())
+	assert.Equal(t, "An Argument你好!", zh.T("HELLO", "An Argument"))
+	assert.Equal(t, "I speak Generic English!", zh.T("ONLY_IN_EN"))
+	assert.Equal(t, "[NOT_EXISTED]", zh.T("NOT_EXISTED"))
+	assertTranslation(t, "Hello An Argument!", "HELLO", "An Argument")
+
+	files := &Localizer{locale: "en-US", messages: map[string]string{"FILES": "{count, plural, one {# file} other {# files}} in {0}"}}
+	assert.Equal(t, "1 file in Docs", files.TN("FILES", 1, "Docs"))
+	assert.Equal(t, "2 files in Docs", files.TN("FILES", 2, "Docs"))
+	shared := &Localizer{locale: "en-US", messages: map[string]string{"SHARED": "{gender, select, female {She} male {He} other {They}} shared {count, plural, one {# file} other {# files}}"}}
+	assert.Equal(t, "She shared 3 files", shared.TN("SHARED", 3, Params{"gender": "female"}))
+
+	_, err = NewLocalizer("e0")
+	assert.Error(t, err, "should error on malformed locale")
+}
@@ -0,0 +1,558 @@
+package i18n
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// Params supplies named arguments to messages with placeholders, e.g.
+//
+//   T("FILES", Params{"count": 3})
+//
+// for the message "{count, number} files".
+type Params map[string]interface{}
+
+// Placeholder describes an argument referenced by a message, e.g. Name
+// "count", Type "number" and Style "integer" for {count, number, integer}.
+type Placeholder struct {
+	Name  string
+	Type  string
+	Style string
+}
+
+// Placeholders returns the placeholders of the message s in order of
+// appearance, or an error if they're malformed. It returns nil for messages
+// formatted with fmt.Sprintf.
+func Placeholders(s string) ([]Placeholder, error) {
+	if !hasPlaceholders(s) {
+		return nil, nil
+	}
+	msg, err := parseMessage(s)
+	if err != nil {
+		return nil, err
+	}
+	return msg.placeholders(nil), nil
+}
+
+// placeholders appends the placeholders of msg and of its branches to
+// placeholders, leaving out the # of plural branches.
+func (msg parsedMessage) placeholders(placeholders []Placeholder) []Placeholder {
+	for _, part := range msg {
+		if part.arg != "" && !part.pound {
+			placeholders = append(placeholders, Placeholder{Name: part.arg, Type: part.typ, Style: part.style})
+		}
+		for _, selector := range part.selectors {
+			placeholders = part.branches[selector].placeholders(placeholders)
+		}
+	}
+	return placeholders
+}
+
+// parsedMessage is a translation parsed into literal text and placeholders.
+//
+// Placeholders follow the ICU MessageFormat syntax: {name} or {name, type} or
+// {name, type, style}, where name is either the index of a positional argument
+// or the name of an argument supplied in Params, made of letters, digits and
+// underscores. Messages with printf verbs, or with braces around anything else,
+// are formatted with fmt.Sprintf instead. Supported types and styles are
+//
+//   number        (none), integer, percent, compact
+//   currency      (none), an ISO 4217 code, symbol, narrow, code
+//   date          (none), short, medium, long, full, ::skeleton
+//   time          (none), short, medium, long, full, ::skeleton
+//   list          (none), and, or, unit
+//   unit          a CLDR unit, optionally followed by long, short or narrow
+//   bytes         (none), rate, long, short, narrow
+//   plural        selector {message} ...
+//   select        selector {message} ...
+//   selectordinal selector {message} ...
+//   ordinal       (none)
+//
+// A currency placeholder formats Money, or amounts in the currency of its
+// style, e.g. {price, currency, EUR narrow}. Date and time placeholders format
+// time.Time values, in the medium length by default, or following a CLDR
+// skeleton, e.g. {when, date, ::yMMMd}. List placeholders join a []string or
+// a List. Unit placeholders format quantities of a unit, e.g. {d, unit,
+// kilometer long}, and bytes placeholders sizes in bytes, or rates in bytes per
+// second with rate, e.g. {speed, bytes, rate}. A plural placeholder picks the
+// message of the CLDR plural category of a number, zero, one, two, few, many
+// or other, unless one of its selectors is =n for the exact number, e.g.
+// {count, plural, =0 {no files} one {# file} other {# files}}. Its other
+// message is required. # stands for the number in its messages. A select
+// placeholder picks the message whose selector is the argument, e.g. a
+// grammatical gender in {gender, select, female {She} male {He} other {They}},
+// or else its required other message. A selectordinal placeholder is like a
+// plural placeholder selecting on the CLDR ordinal category of a number, e.g.
+// {pos, selectordinal, one {#st} two {#nd} few {#rd} other {#th}}, while an
+// ordinal placeholder formats an integer like FormatOrdinal. Plural, select
+// and selectordinal placeholders can be nested in the messages of each other.
+// A placeholder without type formats numbers like number, Money like
+// currency, time.Time as a short date and time, lists like list and any other
+// value with fmt.Sprint.
+//
+// Messages can contain the tags <name>, </name> and <name/> of rich text,
+// which TRich and TPlain render, e.g. "Read the <link>terms</link>". Tags
+// which aren't closed within the message, such as <Enter> in "Press <Enter>",
+// and closing tags which close no tag are text, but tags mustn't cross. Tags
+// with attributes and the HTML void elements, such as <a href="/terms"> or
+// <br>, are kept as text.
+//
+// An apostrophe quotes the braces, # or < following it up to the next
+// apostrophe, so '{' is a literal brace, and two apostrophes are a literal
+// apostrophe.
+type parsedMessage []messagePart
+
+// messagePart is either literal text or a placeholder, if arg isn't empty.
+type messagePart struct {
+	text  string
+	arg   string
+	typ   string
+	style string
+	// whether the placeholder is the # of a plural message
+	pound bool
+	// the messages of plural and select placeholders by selector, and the
+	// selectors in order of appearance
+	branches  map[string]parsedMessage
+	selectors []string
+	// the name and kind of rich text tags, whose text is the tag as written
+	tagName string
+	tagKind tagKind
+}
+
+var (
+	// argNameRegexp matches the names of the arguments of placeholders, the
+	// index of a positional argument or an identifier
+	argNameRegexp = regexp.MustCompile(`^([0-9]+|[A-Za-z_][A-Za-z0-9_]*)$`)
+	// printfVerbRegexp matches the verbs of messages formatted with
+	// fmt.Sprintf, such as %s, %-5d, %[2]v or %%
+	printfVerbRegexp = regexp.MustCompile(`%[-+#0]*(\[[0-9]+\])?([0-9]+|\*)?(\.([0-9]+|\*)?)?(\[[0-9]+\])?[A-Za-z%]`)
+)
+
+// hasPlaceholders tells whether s should be formatted as a message with
+// placeholders rather than with fmt.Sprintf, which it is if it has no printf
+// verbs and the name of each of its placeholders is valid. Printf messages
+// with braces, such as "Use {%s}", are left to fmt.Sprintf.
+func hasPlaceholders(s string) bool {
+	if !strings.ContainsRune(s, '{') || printfVerbRegexp.MatchString(s) {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\'' && i+1 < len(s) && s[i+1] == '\'':
+			i++
+		case s[i] == '\'' && i+1 < len(s) && (s[i+1] == '{' || s[i+1] == '}' || s[i+1] == '<'):
+			end := strings.IndexByte(s[i+1:], '\'')
+			if end < 0 {
+				return true
+			}
+			i += end + 1
+		case s[i] == '{':
+			end := matchingBrace(s, i)
+			inner := s[i+1:]
+			if end >= 0 {
+				inner = s[i+1 : end]
+			}
+			// an unclosed placeholder is a malformed message, not printf
+			if name := strings.TrimSpace(strings.SplitN(inner, ",", 2)[0]); !argNameRegexp.MatchString(name) {
+				return false
+			}
+			if end < 0 {
+				return true
+			}
+			i = end
+		}
+	}
+	return true
+}
+
+// openTag is a tag opened in a message being parsed, at index in the parsed
+// message unless it's raw.
+type openTag struct {
+	part  messagePart
+	raw   bool
+	index int
+}
+
+// parseMessage parses a message with placeholders.
+func parseMessage(s string) (parsedMessage, error) {
+	return parseSubMessage(s, "")
+}
+
+// parseSubMessage parses a message, which is a branch of the plural
+// placeholder of the argument pound if it isn't empty.
+func parseSubMessage(s string, pound string) (parsedMessage, error) {
+	var msg parsedMessage
+	var text strings.Builder
+	// the tags opened and not closed yet, innermost last
+	var open []openTag
+	flush := func() {
+		if text.Len() > 0 {
+			msg = append(msg, messagePart{text: text.String()})
+			text.Reset()
+		}
+	}
+	// tags which are never closed are text, e.g. "Press <Enter>"
+	unclosed := func(tag openTag) {
+		if !tag.raw {
+			msg[tag.index] = messagePart{text: tag.part.text}
+		}
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\'' && i+1 < len(s) && s[i+1] == '\'':
+			text.WriteByte('\'')
+			i++
+		case c == '\'' && i+1 < len(s) && (s[i+1] == '{' || s[i+1] == '}' || s[i+1] == '#' && pound != "" || s[i+1] == '<'):
+			end := strings.IndexByte(s[i+1:], '\'')
+			if end < 0 {
+				text.WriteString(s[i+1:])
+				i = len(s)
+			} else {
+				text.WriteString(s[i+1 : i+1+end])
+				i += end + 1
+			}
+		case c == '{':
+			end := matchingBrace(s, i)
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed placeholder at offset %d", i)
+			}
+			part, err := parsePlaceholder(s[i+1:end], pound)
+			if err != nil {
+				return nil, fmt.Errorf("placeholder at offset %d: %w", i, err)
+			}
+			flush()
+			msg = append(msg, part)
+			i = end
+		case c == '}':
+			return nil, fmt.Errorf("unexpected } at offset %d", i)
+		case c == '#' && pound != "":
+			flush()
+			msg = append(msg, messagePart{arg: pound, typ: "number", pound: true})
+		case c == '<' && tagRegexp.MatchString(s[i:]):
+			part, raw := parseTag(tagRegexp.FindString(s[i:]))
+			i += len(part.text) - 1
+			switch part.tagKind {
+			case tagOpen:
+				if !raw {
+					flush()
+					msg = append(msg, part)
+				}
+				open = append(open, openTag{part: part, raw: raw, index: len(msg) - 1})
+				if raw {
+					// balanced without rendering its closing tag
+					text.WriteString(part.text)
+				}
+				continue
+			case tagClose:
+				j := len(open) - 1
+				for j >= 0 && open[j].part.tagName != part.tagName {
+					j--
+				}
+				if j < 0 {
+					// closes no tag, so it's text
+					text.WriteString(part.text)
+					continue
+				}
+				for _, tag := range open[j+1:] {
+					if strings.Contains(s[i+1:], "</"+tag.part.tagName+">") {
+						return nil, &tagError{offset: i + 1 - len(part.text), err: fmt.Sprintf("%s crosses <%s>", part.text, tag.part.tagName)}
+					}
+					unclosed(tag)
+				}
+				raw = open[j].raw
+				open = open[:j]
+			}
+			if raw {
+				text.WriteString(part.text)
+				continue
+			}
+			flush()
+			msg = append(msg, part)
+		default:
+			text.WriteByte(c)
+		}
+	}
+	for _, tag := range open {
+		unclosed(tag)
+	}
+	flush()
+	return msg, nil
+}
+
+// matchingBrace returns the index of the brace closing the one at s[start],
+// or -1.
+func matchingBrace(s string, start int) int {
+	depth := 0
+	for i := start; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func parsePlaceholder(s string, pound string) (messagePart, error) {
+	fields := strings.SplitN(s, ",", 3)
+	part := messagePart{arg: strings.TrimSpace(fields[0])}
+	if !argNameRegexp.MatchString(part.arg) {
+		return part, fmt.Errorf("invalid argument name %q", part.arg)
+	}
+	if len(fields) > 1 {
+		part.typ = strings.TrimSpace(fields[1])
+	}
+	if len(fields) > 2 {
+		part.style = strings.TrimSpace(fields[2])
+	}
+	switch part.typ {
+	case "":
+		if len(fields) > 1 {
+			return part, fmt.Errorf("missing type of %s", part.arg)
+		}
+	case "number":
+		switch part.style {
+		case "", "integer", "percent", "compact":
+		default:
+			return part, fmt.Errorf("unknown number style %q", part.style)
+		}
+	case "currency":
+		if _, _, err := parseCurrencyStyle(part.style); err != nil {
+			return part, err
+		}
+	case "date", "time":
+		if _, err := parseDateStyle(part.style); err != nil {
+			return part, err
+		}
+	case "list":
+		if _, err := parseListStyle(part.style); err != nil {
+			return part, err
+		}
+	case "unit":
+		if _, _, err := parseUnitStyle(part.style); err != nil {
+			return part, err
+		}
+	case "bytes":
+		if _, _, err := parseBytesStyle(part.style); err != nil {
+			return part, err
+		}
+	case "plural", "selectordinal":
+		var err error
+		if part.branches, part.selectors, err = parseBranches(part.style, part.arg); err != nil {
+			return part, err
+		}
+		for _, selector := range part.selectors {
+			if !isPluralSelector(selector) {
+				return part, fmt.Errorf("unknown plural selector %q", selector)
+			}
+		}
+	case "ordinal":
+		if part.style != "" {
+			return part, fmt.Errorf("unknown ordinal style %q", part.style)
+		}
+	case "select":
+		var err error
+		// the # of an enclosing plural placeholder still stands for its number
+		if part.branches, part.selectors, err = parseBranches(part.style, pound); err != nil {
+			return part, err
+		}
+		for _, selector := range part.selectors {
+			if !selectorRegexp.MatchString(selector) {
+				return part, fmt.Errorf("invalid select selector %q", selector)
+			}
+		}
+	default:
+		return part, fmt.Errorf("unknown type %q", part.typ)
+	}
+	return part, nil
+}
+
+// selectorRegexp matches the selectors of select placeholders.
+var selectorRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// parseBranches parses the messages of the selectors in the style of a
+// plural or select placeholder, e.g. "one {# file} other {# files}", which
+// must include other.
+func parseBranches(s string, pound string) (map[string]parsedMessage, []string, error) {
+	branches := make(map[string]parsedMessage)
+	var selectors []string
+	for s = strings.TrimSpace(s); s != ""; s = strings.TrimSpace(s) {
+		open := strings.IndexByte(s, '{')
+		if open < 0 {
+			return nil, nil, fmt.Errorf("missing message of %q", s)
+		}
+		selector := strings.TrimSpace(s[:open])
+		if selector == "" || strings.ContainsAny(selector, " \t\n}") {
+			return nil, nil, fmt.Errorf("invalid selector %q", selector)
+		}
+		end := matchingBrace(s, open)
+		if end < 0 {
+			return nil, nil, fmt.Errorf("unclosed message of %s", selector)
+		}
+		if _, dup := branches[selector]; dup {
+			return nil, nil, fmt.Errorf("duplicate selector %s", selector)
+		}
+		msg, err := parseSubMessage(s[open+1:end], pound)
+		if err != nil {
+			return nil, nil, fmt.Errorf("message of %s: %w", selector, err)
+		}
+		branches[selector] = msg
+		selectors = append(selectors, selector)
+		s = s[end+1:]
+	}
+	if _, found := branches["other"]; !found {
+		return nil, nil, fmt.Errorf("missing other message")
+	}
+	return branches, selectors, nil
+}
+
+// format formats the message for the given locale. Placeholders whose
+// argument isn't supplied are left as is, the others are isolated as selected
+// by isolation if the locale is right-to-left. Tags are left as written.
+func (msg parsedMessage) format(tag language.Tag, args []interface{}, isolation BidiIsolation) string {
+	return msg.render(tag, args, isolation, nil)
+}
+
+// render formats the message like format, rendering its text, arguments and
+// tags with m unless it's nil.
+func (msg parsedMessage) render(tag language.Tag, args []interface{}, isolation BidiIsolation, m *markup) string {
+	if directionOf(tag) != RightToLeft {
+		isolation = BidiNone
+	}
+	// the content of the open tags, innermost last
+	out := []*strings.Builder{{}}
+	write := func(s string) {
+		out[len(out)-1].WriteString(s)
+	}
+	for _, part := range msg {
+		switch {
+		case part.tagKind != 0 && (m == nil || m.raw):
+			write(part.text)
+			continue
+		case part.tagKind == tagOpen:
+			out = append(out, &strings.Builder{})
+			continue
+		case part.tagKind == tagClose:
+			content := out[len(out)-1].String()
+			out = out[:len(out)-1]
+			write(m.tag(part.tagName, content))
+			continue
+		case part.tagKind == tagSelfClosing:
+			write(m.tag(part.tagName, ""))
+			continue
+		case part.arg == "":
+			write(m.text(part.text))
+			continue
+		}
+		v, found := lookupArg(part.arg, args)
+		if !found {
+			log.Debugf("Argument %s not supplied", part.arg)
+			write(m.text("{" + part.arg + "}"))
+			continue
+		}
+		if part.branches != nil {
+			write(part.branch(tag, v).render(tag, args, isolation, m))
+			continue
+		}
+		arg := m.text(formatArg(tag, part, v))
+		if isolation == BidiHTML && m == nil {
+			arg = html.EscapeString(arg)
+		}
+		write(isolate(arg, isolation))
+	}
+	return out[0].String()
+}
+
+// branch returns the message of a plural, select or selectordinal placeholder
+// for the argument v. The other message is returned if no selector matches,
+// or if the argument of a plural or selectordinal placeholder isn't a number.
+func (part messagePart) branch(tag language.Tag, v interface{}) parsedMessage {
+	if part.typ == "select" {
+		if msg, found := part.branches[fmt.Sprint(v)]; found {
+			return msg
+		}
+		return part.branches["other"]
+	}
+	d, ok := toDecimal(v)
+	if !ok {
+		return part.branches["other"]
+	}
+	// select on the number as formatted for #
+	d = d.round(3)
+	for _, selector := range part.selectors {
+		if exact, ok := parseDecimal(strings.TrimPrefix(selector, "=")); ok && selector[0] == '=' && exact.round(3) == d {
+			return part.branches[selector]
+		}
+	}
+	form := pluralForm(tag, d)
+	if part.typ == "selectordinal" {
+		form = ordinalForm(tag, d)
+	}
+	if msg, found := part.branches[pluralCategories[form]]; found {
+		return msg
+	}
+	return part.branches["other"]
+}
+
+// formatArg formats the argument of a placeholder.
+func formatArg(tag language.Tag, part messagePart, v interface{}) string {
+	switch part.typ {
+	case "number":
+		return formatNumber(tag, v, part.style)
+	case "currency":
+		return formatCurrencyArg(tag, v, part.style)
+	case "date", "time":
+		return formatDateArg(tag, v, part.typ, part.style)
+	case "list":
+		return formatListArg(tag, v, part.style)
+	case "unit", "bytes":
+		return formatUnitArg(tag, v, part.typ, part.style)
+	case "ordinal":
+		return formatOrdinalArg(tag, v)
+	}
+	switch v := v.(type) {
+	case Money:
+		return formatCurrency(tag, v.Amount, v.Currency, CurrencySymbol)
+	case time.Time:
+		return formatDateTime(tag, v, "datetime", "short")
+	case List, []string:
+		return formatListArg(tag, v, "")
+	}
+	return formatNumber(tag, v, "")
+}
+
+// lookupArg finds the argument named name, which is either the index of a
+// positional argument, not counting Params, or a key of a Params argument.
+func lookupArg(name string, args []interface{}) (interface{}, bool) {
+	if i, err := strconv.Atoi(name); err == nil {
+		for _, arg := range args {
+			if _, isParams := arg.(Params); isParams {
+				continue
+			}
+			if i == 0 {
+				return arg, true
+			}
+			i--
+		}
+		return nil, false
+	}
+	for _, arg := range args {
+		if params, isParams := arg.(Params); isParams {
+			if v, found := params[name]; found {
+				return v, true
+			}
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,170 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestParseMessage(t *testing.T) {
+	msg, err := parseMessage("You have {count, number} new {0}, '{'quoted'}' and it''s {rate, number, percent}")
+	if assert.NoError(t, err) {
+		assert.Equal(t, parsedMessage{
+			{text: "You have "},
+			{arg: "count", typ: "number"},
+			{text: " new "},
+			{arg: "0"},
+			{text: ", {quoted} and it's "},
+			{arg: "rate", typ: "number", style: "percent"},
+		}, msg)
+	}
+	for _, s := range []string{"{unclosed", "stray }", "{}", "{count, money}", "{count, number, weird}", "{count,}"} {
+		_, err := parseMessage(s)
+		assert.Error(t, err, "should fail to parse %q", s)
+	}
+}
+
+func TestFormatMessage(t *testing.T) {
+	format := func(locale string, s string, args ...interface{}) string {
+		msg, err := parseMessage(s)
+		if !assert.NoError(t, err) {
+			return ""
+		}
+		return msg.format(language.Make(locale), args, BidiControls)
+	}
+	assert.Equal(t, "1,234 files in Docs", format("en", "{count, number} files in {0}", "Docs", Params{"count": 1234}))
+	assert.Equal(t, "1.234 Dateien in Docs", format("de", "{count, number} Dateien in {0}", Params{"count": 1234}, "Docs"))
+	assert.Equal(t, "12K users, 50% done", format("en", "{users, number, compact} users, {0, number, percent} done", 0.5, Params{"users": 12000}))
+	assert.Equal(t, "{missing} and {1}", format("en", "{missing} and {1}", "only one"))
+}
+
+func TestFormatPlural(t *testing.T) {
+	format := func(locale string, s string, args ...interface{}) string {
+		msg, err := parseMessage(s)
+		if !assert.NoError(t, err) {
+			return ""
+		}
+		return msg.format(language.Make(locale), args, BidiControls)
+	}
+	files := "{count, plural, =0 {No files} one {# file} other {# files}} in {0}"
+	assert.Equal(t, "No files in Docs", format("en", files, "Docs", Params{"count": 0}))
+	assert.Equal(t, "1 file in Docs", format("en", files, "Docs", Params{"count": 1}))
+	assert.Equal(t, "1,234 files in Docs", format("en", files, "Docs", Params{"count": 1234}))
+	assert.Equal(t, "1.5 files in Docs", format("en", files, "Docs", Params{"count": 1.5}))
+	assert.Equal(t, "1 file in Docs", format("en", files, "Docs", Params{"count": 1.0001}), "should select on the number as shown")
+	assert.Equal(t, "No files in Docs", format("en", "{0, plural, =0.0 {No files} other {# files}} in Docs", 0))
+
+	ru := "{n, plural, one {# файл} few {# файла} many {# файлов} other {# файла}}"
+	for n, expected := range map[interface{}]string{1: "1 файл", 3: "3 файла", 5: "5 файлов", 21: "21 файл", 1.5: "1,5 файла"} {
+		assert.Equal(t, expected, format("ru", ru, Params{"n": n}))
+	}
+	assert.Equal(t, "3件", format("ja", "{n, plural, other {#件}}", Params{"n": 3}))
+	assert.Equal(t, "'# 2 {0}", format("en", "{n, plural, other {'''#' # '{0}'}}", Params{"n": 2}))
+	assert.Equal(t, "# and {n}", format("en", "# and {n}"), "# should be literal outside plural messages")
+
+	for _, s := range []string{
+		"{n, plural}",
+		"{n, plural, one {# file}}",
+		"{n, plural, some {x} other {y}}",
+		"{n, plural, =x {x} other {y}}",
+		"{n, plural, one {x} one {y} other {z}}",
+		"{n, plural, one other {y}}",
+		"{n, plural, other {{unclosed}}",
+		"{n, plural, other {y} trailing}",
+	} {
+		_, err := parseMessage(s)
+		assert.Error(t, err, "should fail to parse %q", s)
+	}
+	p, err := Placeholders("{n, plural, one {{name} has # file} other {{name} has # files}}")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []Placeholder{
+			{Name: "n", Type: "plural", Style: "one {{name} has # file} other {{name} has # files}"},
+			{Name: "name"},
+			{Name: "name"},
+		}, p)
+	}
+}
+
+func TestFormatSelect(t *testing.T) {
+	format := func(locale string, s string, args ...interface{}) string {
+		msg, err := parseMessage(s)
+		if !assert.NoError(t, err) {
+			return ""
+		}
+		return msg.format(language.Make(locale), args, BidiControls)
+	}
+	shared := "{gender, select, female {She} male {He} other {They}} shared {count, plural, one {# file} other {# files}}"
+	assert.Equal(t, "She shared 3 files", format("en", shared, Params{"gender": "female", "count": 3}))
+	assert.Equal(t, "He shared 1 file", format("en", shared, Params{"gender": "male", "count": 1}))
+	assert.Equal(t, "They shared 2 files", format("en", shared, Params{"gender": "nonbinary", "count": 2}), "should fall back to other")
+	assert.Equal(t, "They shared 2 files", format("en", shared, Params{"gender": nil, "count": 2}))
+
+	nested := "{count, plural, one {{gender, select, female {Sie hat # Datei} other {Er hat # Datei}}} other {{gender, select, female {Sie hat # Dateien} other {Er hat # Dateien}}}} geteilt"
+	assert.Equal(t, "Sie hat 1 Datei geteilt", format("de", nested, Params{"gender": "female", "count": 1}))
+	assert.Equal(t, "Er hat 1.000 Dateien geteilt", format("de", nested, Params{"gender": "male", "count": 1000}))
+	assert.Equal(t, "Download for macOS", format("en", "Download for {0, select, darwin {macOS} windows {Windows} other {Linux}}", "darwin"))
+	assert.Equal(t, "on", format("en", "{enabled, select, true {on} other {off}}", Params{"enabled": true}))
+	assert.Equal(t, "# {x}", format("en", "{x, select, other {# '{x}'}}", Params{"x": 1}), "# should be literal outside plural messages")
+
+	for _, s := range []string{
+		"{g, select}",
+		"{g, select, female {She}}",
+		"{g, select, =1 {x} other {y}}",
+		"{g, select, female {x} female {y} other {z}}",
+	} {
+		_, err := parseMessage(s)
+		assert.Error(t, err, "should fail to parse %q", s)
+	}
+}
+
+func TestPlaceholders(t *testing.T) {
+	p, err := Placeholders("{0} has {count, number, integer} files")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []Placeholder{{Name: "0"}, {Name: "count", Type: "number", Style: "integer"}}, p)
+	}
+	p, err = Placeholders("Hello %s!")
+	assert.NoError(t, err)
+	assert.Nil(t, p, "printf messages have no placeholders")
+	_, err = Placeholders("{broken")
+	assert.Error(t, err)
+}
+
+func TestTranslatePlaceholders(t *testing.T) {
+	defer restoreState()()
+	SetMessagesFunc(func(path string) ([]byte, error) {
+		switch path {
+		case "en.json":
+			return []byte(`{"FILES": "{count, number} files in {0}", "BROKEN": "{count, number %d"}`), nil
+		case "de.json":
+			return []byte(`{"FILES": "{count, number} Dateien in {0}"}`), nil
+		}
+		return nil, nil
+	})
+	if assert.NoError(t, setLocale("de")) {
+		assertTranslation(t, "1.234,5 Dateien in Docs", "FILES", "Docs", Params{"count": 1234.5})
+		assertTranslation(t, "{count, number 5", "BROKEN", 5)
+	}
+}
+
+func TestPrintfWithBraces(t *testing.T) {
+	l := &Localizer{locale: "en-US", messages: map[string]string{
+		"USE":     "Use {%s}",
+		"CLOCK":   "It's {%d} o'clock",
+		"JSON":    `Send {"id": %d}`,
+		"PERCENT": "{%.1f%%}",
+		"BROKEN":  "{count, number %d",
+	}}
+	assert.Equal(t, "Use {x}", l.T("USE", "x"))
+	assert.Equal(t, "It's {5} o'clock", l.T("CLOCK", 5))
+	assert.Equal(t, `Send {"id": 7}`, l.T("JSON", 7))
+	assert.Equal(t, "{12.5%}", l.T("PERCENT", 12.5))
+	assert.Equal(t, "{count, number 5", l.T("BROKEN", 5))
+	for _, s := range []string{"Use {%s}", "It's {%d} o'clock", `Send {"id": %d}`, "{user-name}"} {
+		p, err := Placeholders(s)
+		assert.NoError(t, err, s)
+		assert.Nil(t, p, "%s should be formatted with printf", s)
+	}
+	_, err := parseMessage("{%s}")
+	assert.Error(t, err, "should reject invalid argument names")
+}
@@ -0,0 +1,486 @@
+package i18n
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// nativeNumbering maps languages to the CLDR numbering system of their native
+// digits, used when UseNativeDigits is on.
+var nativeNumbering = map[string]string{
+	"ar": "arab",
+	"as": "beng",
+	"bn": "beng",
+	"bo": "tibt",
+	"fa": "arabext",
+	"gu": "gujr",
+	"hi": "deva",
+	"km": "khmr",
+	"kn": "knda",
+	"lo": "laoo",
+	"ml": "mlym",
+	"mr": "deva",
+	"my": "mymr",
+	"ne": "deva",
+	"or": "orya",
+	"pa": "guru",
+	"ps": "arabext",
+	"ta": "tamldec",
+	"te": "telu",
+	"th": "thai",
+	"ur": "arabext",
+}
+
+// minimumGroupingDigits lists the locales which, according to CLDR, only group
+// the integer digits of numbers with at least 5 of them.
+var minimumGroupingDigits = map[string]int{
+	"es":    2,
+	"pl":    2,
+	"pt-PT": 2,
+}
+
+var (
+	nativeDigits   bool
+	numberFormats  = make(map[string]*numberFormat)
+	numberFormatMx sync.Mutex
+)
+
+// UseNativeDigits makes numbers be formatted with the native digits of the
+// current language where it has some, e.g. Devanagari digits for Hindi,
+// instead of the digits CLDR uses by default for the locale.
+func UseNativeDigits(native bool) {
+	trMutex.Lock()
+	defer trMutex.Unlock()
+	nativeDigits = native
+}
+
+// FormatNumber formats n, which can be a Decimal or any integer or floating
+// point number, as a decimal number following the conventions of the current
+// locale, e.g. "1,234,567.891" in en-US and "1.234.567,891" in de. At most 3
+// fraction digits are shown.
+func FormatNumber(n interface{}) string {
+	return currentLocalizer().FormatNumber(n)
+}
+
+// FormatPercent formats n as a percentage following the conventions of the
+// current locale, e.g. 0.25 as "25%" in en-US and "25 %" in fr.
+func FormatPercent(n interface{}) string {
+	return currentLocalizer().FormatPercent(n)
+}
+
+// FormatCompact formats n in the short compact form of the current locale,
+// e.g. 1234 as "1.2K" in en-US and 123456 as "12万" in zh.
+func FormatCompact(n interface{}) string {
+	return currentLocalizer().FormatCompact(n)
+}
+
+// localeTag returns the language tag used to format values for the given
+// locale.
+func localeTag(locale string, native bool) language.Tag {
+	if locale == "" {
+		locale = defaultLocale
+	}
+	tag := language.Make(locale)
+	if native {
+		base, _ := tag.Base()
+		if nu, found := nativeNumbering[base.String()]; found {
+			if t, err := tag.SetTypeForKey("nu", nu); err == nil {
+				tag = t
+			}
+		}
+	}
+	return tag
+}
+
+// formatNumber formats n in the given style, which is one of "", "integer",
+// "percent" or "compact". Values which aren't numbers are formatted with
+// fmt.Sprint.
+func formatNumber(tag language.Tag, n interface{}, style string) string {
+	d, ok := toDecimal(n)
+	if !ok {
+		return fmt.Sprint(n)
+	}
+	f := numberFormatFor(tag)
+	switch style {
+	case "integer":
+		return f.format(d.round(0), 0)
+	case "percent":
+		return strings.Replace(f.percent, "{0}", f.format(d.shift(2).round(0), 0), 1)
+	case "compact":
+		return f.compact(tag, d)
+	default:
+		return f.format(d.round(3), 0)
+	}
+}
+
+// Decimal is an exact decimal number, for amounts such as prices which must
+// not suffer from floating point rounding errors. The zero value is 0.
+type Decimal struct {
+	neg bool
+	// integer digits, without leading zeros
+	integer string
+	// fraction digits, without trailing zeros
+	fraction string
+}
+
+// toDecimal converts Decimals, integers and floating point numbers to a
+// Decimal.
+func toDecimal(n interface{}) (Decimal, bool) {
+	switch v := n.(type) {
+	case Decimal:
+		return v, true
+	case int:
+		return parseDecimal(strconv.FormatInt(int64(v), 10))
+	case int8:
+		return parseDecimal(strconv.FormatInt(int64(v), 10))
+	case int16:
+		return parseDecimal(strconv.FormatInt(int64(v), 10))
+	case int32:
+		return parseDecimal(strconv.FormatInt(int64(v), 10))
+	case int64:
+		return parseDecimal(strconv.FormatInt(v, 10))
+	case uint:
+		return parseDecimal(strconv.FormatUint(uint64(v), 10))
+	case uint8:
+		return parseDecimal(strconv.FormatUint(uint64(v), 10))
+	case uint16:
+		return parseDecimal(strconv.FormatUint(uint64(v), 10))
+	case uint32:
+		return parseDecimal(strconv.FormatUint(uint64(v), 10))
+	case uint64:
+		return parseDecimal(strconv.FormatUint(v, 10))
+	case float32:
+		return floatDecimal(float64(v), 32)
+	case float64:
+		return floatDecimal(v, 64)
+	}
+	return Decimal{}, false
+}
+
+func floatDecimal(f float64, bitSize int) (Decimal, bool) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Decimal{}, false
+	}
+	return parseDecimal(strconv.FormatFloat(f, 'f', -1, bitSize))
+}
+
+// ParseDecimal parses a decimal number such as "-1234.50".
+func ParseDecimal(s string) (Decimal, error) {
+	d, ok := parseDecimal(s)
+	if !ok {
+		return Decimal{}, fmt.Errorf("Malformated decimal number %s", s)
+	}
+	return d, nil
+}
+
+// NewDecimal returns the Decimal unscaled * 10^-scale, e.g. 19.99 for
+// NewDecimal(1999, 2).
+func NewDecimal(unscaled int64, scale int) Decimal {
+	d, _ := parseDecimal(strconv.FormatInt(unscaled, 10))
+	return d.shift(-scale)
+}
+
+func parseDecimal(s string) (Decimal, bool) {
+	var d Decimal
+	if strings.HasPrefix(s, "-") {
+		d.neg = true
+		s = s[1:]
+	} else if strings.HasPrefix(s, "+") {
+		s = s[1:]
+	}
+	parts := strings.SplitN(s, ".", 2)
+	if parts[0] == "" && (len(parts) == 1 || parts[1] == "") {
+		return Decimal{}, false
+	}
+	for _, p := range parts {
+		for _, r := range p {
+			if r < '0' || r > '9' {
+				return Decimal{}, false
+			}
+		}
+	}
+	d.integer = strings.TrimLeft(parts[0], "0")
+	if len(parts) == 2 {
+		d.fraction = strings.TrimRight(parts[1], "0")
+	}
+	if d.isZero() {
+		d.neg = false
+	}
+	return d, true
+}
+
+func (d Decimal) isZero() bool {
+	return d.integer == "" && d.fraction == ""
+}
+
+// shift multiplies d by 10^n.
+func (d Decimal) shift(n int) Decimal {
+	digits := d.integer + d.fraction
+	point := len(d.integer) + n
+	for point < 0 {
+		digits = "0" + digits
+		point++
+	}
+	for point > len(digits) {
+		digits += "0"
+	}
+	return Decimal{
+		neg:      d.neg,
+		integer:  strings.TrimLeft(digits[:point], "0"),
+		fraction: strings.TrimRight(digits[point:], "0"),
+	}
+}
+
+// round rounds d to at most n fraction digits, rounding half to even.
+func (d Decimal) round(n int) Decimal {
+	if len(d.fraction) <= n {
+		return d
+	}
+	digits := []byte(d.integer + d.fraction[:n])
+	rest := d.fraction[n:]
+	up := rest[0] > '5' || rest[0] == '5' && (strings.TrimRight(rest[1:], "0") != "" ||
+		len(digits) > 0 && (digits[len(digits)-1]-'0')%2 == 1)
+	if up {
+		i := len(digits) - 1
+		for ; i >= 0 && digits[i] == '9'; i-- {
+			digits[i] = '0'
+		}
+		if i < 0 {
+			digits = append([]byte{'1'}, digits...)
+		} else {
+			digits[i]++
+		}
+	}
+	point := len(digits) - n
+	r := Decimal{
+		neg:      d.neg,
+		integer:  strings.TrimLeft(string(digits[:point]), "0"),
+		fraction: strings.TrimRight(string(digits[point:]), "0"),
+	}
+	if r.isZero() {
+		r.neg = false
+	}
+	return r
+}
+
+// String returns d in the form "-1234.5", without trailing fraction zeros.
+func (d Decimal) String() string {
+	s := d.integer
+	if s == "" {
+		s = "0"
+	}
+	if d.fraction != "" {
+		s += "." + d.fraction
+	}
+	if d.neg {
+		s = "-" + s
+	}
+	return s
+}
+
+// numberFormat holds the CLDR number symbols of a locale.
+type numberFormat struct {
+	digits      [10]string
+	decimal     string
+	group       string
+	minusPrefix string
+	minusSuffix string
+	// sizes of the first group of integer digits left of the decimal mark,
+	// and of the other groups
+	primary   int
+	secondary int
+	// minimum number of digits in the leftmost group for grouping to happen
+	minGrouping int
+	// percent pattern, with {0} standing for the number
+	percent string
+}
+
+// numberFormatFor returns the number format of tag. The symbols are derived
+// from numbers formatted by golang.org/x/text, which carries the CLDR data.
+func numberFormatFor(tag language.Tag) *numberFormat {
+	key := tag.String()
+	numberFormatMx.Lock()
+	defer numberFormatMx.Unlock()
+	if f, found := numberFormats[key]; found {
+		return f
+	}
+	p := message.NewPrinter(tag)
+	f := &numberFormat{minGrouping: 1}
+	toASCII := make(map[string]string, 10)
+	for i := range f.digits {
+		f.digits[i] = p.Sprint(number.Decimal(i))
+		toASCII[f.digits[i]] = strconv.Itoa(i)
+	}
+	ascii := func(s string) string {
+		var b strings.Builder
+		for _, r := range s {
+			if d, found := toASCII[string(r)]; found {
+				b.WriteString(d)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+		return b.String()
+	}
+
+	s := ascii(p.Sprint(number.Decimal(12345678.5, number.MinFractionDigits(1))))
+	if i := strings.LastIndex(s, "8"); i >= 0 && strings.HasSuffix(s, "5") {
+		f.decimal = s[i+1 : len(s)-1]
+		integer := s[:i+1]
+		if j := strings.IndexFunc(integer[1:], func(r rune) bool { return r < '0' || r > '9' }); j >= 0 {
+			sepStart := j + 1
+			sepEnd := sepStart
+			for sepEnd < len(integer) && (integer[sepEnd] < '0' || integer[sepEnd] > '9') {
+				sepEnd++
+			}
+			f.group = integer[sepStart:sepEnd]
+			groups := strings.Split(integer, f.group)
+			f.primary = len(groups[len(groups)-1])
+			f.secondary = f.primary
+			if len(groups) > 2 {
+				f.secondary = len(groups[len(groups)-2])
+			}
+		}
+	}
+	if f.decimal == "" {
+		f.decimal = "."
+	}
+
+	s = ascii(p.Sprint(number.Decimal(-1)))
+	if i := strings.Index(s, "1"); i >= 0 {
+		f.minusPrefix, f.minusSuffix = s[:i], s[i+1:]
+	} else {
+		f.minusPrefix = "-"
+	}
+
+	f.percent = strings.Replace(ascii(p.Sprint(number.Percent(0.25))), "25", "{0}", 1)
+	if !strings.Contains(f.percent, "{0}") {
+		f.percent = "{0}%"
+	}
+
+	if n, found := minimumGroupingDigits[key]; found {
+		f.minGrouping = n
+	} else if base, _ := tag.Base(); minimumGroupingDigits[base.String()] > 0 {
+		f.minGrouping = minimumGroupingDigits[base.String()]
+	}
+	numberFormats[key] = f
+	return f
+}
+
+// format formats d, which must already be rounded, with at least minFraction
+// fraction digits.
+func (f *numberFormat) format(d Decimal, minFraction int) string {
+	integer := d.integer
+	if integer == "" {
+		integer = "0"
+	}
+	var b strings.Builder
+	if d.neg {
+		b.WriteString(f.minusPrefix)
+	}
+	grouped := f.primary > 0 && len(integer) >= f.primary+f.minGrouping
+	for i, c := range integer {
+		if grouped && i > 0 {
+			left := len(integer) - i
+			if left == f.primary || left > f.primary && (left-f.primary)%f.secondary == 0 {
+				b.WriteString(f.group)
+			}
+		}
+		b.WriteString(f.digits[c-'0'])
+	}
+	fraction := d.fraction
+	for len(fraction) < minFraction {
+		fraction += "0"
+	}
+	if fraction != "" {
+		b.WriteString(f.decimal)
+		for _, c := range fraction {
+			b.WriteString(f.digits[c-'0'])
+		}
+	}
+	if d.neg {
+		b.WriteString(f.minusSuffix)
+	}
+	return b.String()
+}
+
+// compactUnit is a CLDR short compact decimal pattern. Numbers with at least
+// min integer digits are divided by 10^(div-1) and substituted for {0} in the
+// pattern. An empty pattern leaves the number as is.
+type compactUnit struct {
+	min     int
+	div     int
+	pattern string
+}
+
+// compactUnits holds the short compact decimal patterns of common languages,
+// from CLDR. Languages not listed use the English ones.
+var compactUnits = map[string][]compactUnit{
+	"en": {{4, 4, "{0}K"}, {7, 7, "{0}M"}, {10, 10, "{0}B"}, {13, 13, "{0}T"}},
+	"ar": {{4, 4, "{0} ألف"}, {7, 7, "{0} مليون"}, {10, 10, "{0} مليار"}, {13, 13, "{0} ترليون"}},
+	"de": {{4, 4, ""}, {7, 7, "{0} Mio."}, {10, 10, "{0} Mrd."}, {13, 13, "{0} Bio."}},
+	"es": {{4, 4, "{0} mil"}, {7, 7, "{0} M"}, {11, 10, "{0} mil M"}, {13, 13, "{0} B"}},
+	"fa": {{4, 4, "{0} هزار"}, {7, 7, "{0} میلیون"}, {10, 10, "{0} میلیارد"}, {13, 13, "{0} هزارمیلیارد"}},
+	"fr": {{4, 4, "{0} k"}, {7, 7, "{0} M"}, {10, 10, "{0} Md"}, {13, 13, "{0} Bn"}},
+	"hi": {{4, 4, "{0} हज़ार"}, {6, 6, "{0} लाख"}, {8, 8, "{0} क॰"}, {10, 10, "{0} अ॰"}, {12, 12, "{0} ख॰"}},
+	"it": {{4, 4, ""}, {7, 7, "{0} Mln"}, {10, 10, "{0} Mrd"}, {13, 13, "{0} Bln"}},
+	"ja": {{5, 5, "{0}万"}, {9, 9, "{0}億"}, {13, 13, "{0}兆"}},
+	"ko": {{4, 4, "{0}천"}, {5, 5, "{0}만"}, {9, 9, "{0}억"}, {13, 13, "{0}조"}},
+	"pt": {{4, 4, "{0} mil"}, {7, 7, "{0} mi"}, {10, 10, "{0} bi"}, {13, 13, "{0} tri"}},
+	"ru": {{4, 4, "{0} тыс."}, {7, 7, "{0} млн"}, {10, 10, "{0} млрд"}, {13, 13, "{0} трлн"}},
+	"tr": {{4, 4, "{0} B"}, {7, 7, "{0} Mn"}, {10, 10, "{0} Mr"}, {13, 13, "{0} Tn"}},
+	"vi": {{4, 4, "{0} N"}, {7, 7, "{0} Tr"}, {10, 10, "{0} T"}, {13, 13, "{0} NT"}},
+	"zh": {{5, 5, "{0}万"}, {9, 9, "{0}亿"}, {13, 13, "{0}万亿"}},
+}
+
+// compact formats d in the short compact form of tag. Like CLDR, it keeps two
+// significant digits for numbers below 100 after division, and no fraction
+// digits otherwise.
+func (f *numberFormat) compact(tag language.Tag, d Decimal) string {
+	base, _ := tag.Base()
+	units, found := compactUnits[base.String()]
+	if !found {
+		units = compactUnits["en"]
+	}
+	abs := d
+	abs.neg = false
+	for {
+		var unit compactUnit
+		for _, u := range units {
+			if len(abs.integer) >= u.min {
+				unit = u
+			}
+		}
+		scaled := abs
+		if unit.pattern != "" {
+			scaled = abs.shift(1 - unit.div)
+		}
+		if len(scaled.integer) < 2 {
+			scaled = scaled.round(1)
+		} else {
+			scaled = scaled.round(0)
+		}
+		// rounding might carry into the next unit, e.g. 999999 to 1000K
+		rounded := scaled
+		if unit.pattern != "" {
+			rounded = scaled.shift(unit.div - 1)
+		}
+		if len(rounded.integer) > len(abs.integer) {
+			abs = rounded
+			continue
+		}
+		scaled.neg = d.neg && !scaled.isZero()
+		s := f.format(scaled, 0)
+		if unit.pattern == "" {
+			return s
+		}
+		return strings.Replace(unit.pattern, "{0}", s, 1)
+	}
+}
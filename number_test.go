@@ -0,0 +1,75 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestFormatNumber(t *testing.T) {
+	for _, c := range []struct {
+		locale   string
+		n        interface{}
+		style    string
+		expected string
+	}{
+		{"en-US", 1234567, "", "1,234,567"},
+		{"en-US", 1234567.891, "", "1,234,567.891"},
+		{"en-US", 1.23456, "", "1.235"},
+		{"en-US", -0.5, "", "-0.5"},
+		{"en-US", 2.5, "integer", "2"},
+		{"en-US", uint8(7), "", "7"},
+		{"de", 1234567.891, "", "1.234.567,891"},
+		{"fr", 1234567, "", "1 234 567"},
+		{"hi", 1234567, "", "12,34,567"},
+		{"es", 1234, "", "1234"},
+		{"es", 12345, "", "12.345"},
+		{"ar", 1234.5, "", "١٬٢٣٤٫٥"},
+		{"en-US", 0.256, "percent", "26%"},
+		{"fr", 0.25, "percent", "25 %"},
+		{"en-US", 999, "compact", "999"},
+		{"en-US", 1234, "compact", "1.2K"},
+		{"en-US", 12345, "compact", "12K"},
+		{"en-US", 999999, "compact", "1M"},
+		{"en-US", -1500000, "compact", "-1.5M"},
+		{"zh", 123456, "compact", "12万"},
+		{"ja", 120000000, "compact", "1.2億"},
+		{"de", 1234, "compact", "1.234"},
+		{"de", 2500000, "compact", "2,5 Mio."},
+		{"en-US", "not a number", "", "not a number"},
+	} {
+		assert.Equal(t, c.expected, formatNumber(language.Make(c.locale), c.n, c.style), "%v in %v with style %q", c.n, c.locale, c.style)
+	}
+	assert.Equal(t, "१२,३४,५६७", formatNumber(localeTag("hi", true), 1234567, ""), "should use native digits")
+	assert.Equal(t, "1,234", formatNumber(localeTag("en", true), 1234, ""), "should keep latin digits if there are no native ones")
+}
+
+func TestFormatNumberCurrentLocale(t *testing.T) {
+	defer restoreState()()
+	SetMessagesDir("locale")
+	if assert.NoError(t, setLocale("zh_CN")) {
+		assert.Equal(t, "1,234.5", FormatNumber(1234.5))
+		assert.Equal(t, "50%", FormatPercent(0.5))
+		assert.Equal(t, "1.2万", FormatCompact(12000))
+	}
+}
+
+func TestDecimal(t *testing.T) {
+	d, ok := parseDecimal("-0012.3400")
+	if assert.True(t, ok) {
+		assert.Equal(t, "-12.34", d.String())
+		assert.Equal(t, "-1234", d.shift(2).String())
+		assert.Equal(t, "-0.1234", d.shift(-2).String())
+		assert.Equal(t, "-12.3", d.round(1).String())
+		assert.Equal(t, "-12", d.round(0).String())
+	}
+	for s, expected := range map[string]string{"0.5": "0", "1.5": "2", "2.5": "2", "2.51": "3", "9.99": "10", "-0.4": "0"} {
+		d, _ := parseDecimal(s)
+		assert.Equal(t, expected, d.round(0).String(), "rounding %v", s)
+	}
+	_, ok = parseDecimal("1.2.3")
+	assert.False(t, ok)
+	_, ok = parseDecimal("-")
+	assert.False(t, ok)
+}
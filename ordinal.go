@@ -0,0 +1,87 @@
+package i18n
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// ordinalPatterns are the CLDR patterns of ordinal numbers written in digits,
+// by ordinal plural category, in the masculine form where it varies. Languages
+// with a single pattern only list other.
+var ordinalPatterns = map[string]map[string]string{
+	"cs": {"other": "{0}."},
+	"da": {"other": "{0}."},
+	"de": {"other": "{0}."},
+	"en": {"one": "{0}st", "two": "{0}nd", "few": "{0}rd", "other": "{0}th"},
+	"es": {"other": "{0}.º"},
+	"fi": {"other": "{0}."},
+	"fr": {"one": "{0}er", "other": "{0}e"},
+	"it": {"other": "{0}º"},
+	"ja": {"other": "第{0}"},
+	"ko": {"other": "{0}번째"},
+	"nb": {"other": "{0}."},
+	"nl": {"other": "{0}e"},
+	"pl": {"other": "{0}."},
+	"pt": {"other": "{0}º"},
+	"ru": {"other": "{0}-й"},
+	"sv": {"one": "{0}:a", "other": "{0}:e"},
+	"tr": {"other": "{0}."},
+	"uk": {"other": "{0}-й"},
+	"zh": {"other": "第{0}"},
+}
+
+// FormatOrdinal formats n as an ordinal number in the current locale, e.g.
+// "2nd" in English, "2e" in French and "2." in German. Languages without
+// ordinal patterns get the plain number.
+func FormatOrdinal(n int64) string {
+	return currentLocalizer().FormatOrdinal(n)
+}
+
+// FormatOrdinal formats n like the package level FormatOrdinal, in the locale
+// of l.
+func (l *Localizer) FormatOrdinal(n int64) string {
+	return formatOrdinal(l.tag(), n)
+}
+
+func formatOrdinal(tag language.Tag, n int64) string {
+	base, _ := tag.Base()
+	patterns, found := ordinalPatterns[base.String()]
+	if !found {
+		// English suffixes would be wrong for most languages
+		return formatNumber(tag, n, "integer")
+	}
+	pattern, found := patterns[pluralCategories[ordinalForm(tag, NewDecimal(n, 0))]]
+	if !found {
+		pattern = patterns["other"]
+	}
+	return strings.Replace(pattern, "{0}", formatNumber(tag, n, "integer"), 1)
+}
+
+// ordinalForm returns the CLDR ordinal plural form of d in the language of
+// tag. Numbers with fraction digits have no ordinal, and are other.
+func ordinalForm(tag language.Tag, d Decimal) plural.Form {
+	if d.fraction != "" {
+		return plural.Other
+	}
+	// operands too large for an int may be passed modulo 10,000,000
+	i, _ := strconv.Atoi(lastDigits(d.integer, 7))
+	return plural.Ordinal.MatchPlural(tag, i, 0, 0, 0, 0)
+}
+
+// formatOrdinalArg formats the argument of an ordinal placeholder, which must
+// be an integer.
+func formatOrdinalArg(tag language.Tag, v interface{}) string {
+	d, ok := toDecimal(v)
+	if !ok || d.fraction != "" {
+		return fmt.Sprint(v)
+	}
+	n, err := strconv.ParseInt(d.String(), 10, 64)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return formatOrdinal(tag, n)
+}
@@ -0,0 +1,52 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestFormatOrdinal(t *testing.T) {
+	for locale, expected := range map[string][]string{
+		"en": {"1st", "2nd", "3rd", "4th", "11th", "12th", "13th", "21st", "22nd", "101st", "1,002nd"},
+		"fr": {"1er", "2e", "3e", "4e", "11e", "12e", "13e", "21e", "22e", "101e", "1\u00a0002e"},
+		"de": {"1.", "2.", "3.", "4.", "11.", "12.", "13.", "21.", "22.", "101.", "1.002."},
+		"sv": {"1:a", "2:a", "3:e", "4:e", "11:e", "12:e", "13:e", "21:a", "22:a", "101:a", "1\u00a0002:a"},
+		"zh": {"第1", "第2", "第3", "第4", "第11", "第12", "第13", "第21", "第22", "第101", "第1,002"},
+	} {
+		l := &Localizer{locale: locale}
+		for i, n := range []int64{1, 2, 3, 4, 11, 12, 13, 21, 22, 101, 1002} {
+			assert.Equal(t, expected[i], l.FormatOrdinal(n), "%d in %s", n, locale)
+		}
+	}
+	assert.Equal(t, "5", (&Localizer{locale: "hu"}).FormatOrdinal(5), "should fall back to the number")
+	assert.Equal(t, "٥", (&Localizer{locale: "ar"}).FormatOrdinal(5))
+	assert.Equal(t, "1,002", (&Localizer{locale: "he"}).FormatOrdinal(1002))
+	assert.Equal(t, "-1st", formatOrdinal(language.English, -1))
+
+	format := func(locale string, s string, args ...interface{}) string {
+		msg, err := parseMessage(s)
+		if !assert.NoError(t, err) {
+			return ""
+		}
+		return msg.format(language.Make(locale), args, BidiControls)
+	}
+	queue := "You are {pos, selectordinal, =1 {next} one {#st} two {#nd} few {#rd} other {#th}} in the queue"
+	for n, expected := range map[interface{}]string{1: "next", 2: "2nd", 3: "3rd", 11: "11th", 23: "23rd", 1.5: "1.5th"} {
+		assert.Equal(t, "You are "+expected+" in the queue", format("en", queue, Params{"pos": n}))
+	}
+	assert.Equal(t, "Tu es 1re", format("fr", "Tu es {0, selectordinal, one {#re} other {#e}}", 1))
+	assert.Equal(t, "Tu es 2e", format("fr", "Tu es {0, selectordinal, one {#re} other {#e}}", 2))
+	assert.Equal(t, "Du bist 3.", format("de", "Du bist {0, ordinal}", 3))
+	assert.Equal(t, "You are 22nd", format("en", "You are {0, ordinal}", int64(22)))
+	assert.Equal(t, "You are 2.5", format("en", "You are {0, ordinal}", 2.5), "should only format integers as ordinals")
+	for _, s := range []string{
+		"{n, selectordinal, one {#st}}",
+		"{n, selectordinal, first {#st} other {#th}}",
+		"{n, ordinal, short}",
+	} {
+		_, err := parseMessage(s)
+		assert.Error(t, err, "should fail to parse %q", s)
+	}
+}
@@ -0,0 +1,88 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Overrides are translations applied over those of Localizers, e.g. the brand
+// names of a white-label customer in a multi-tenant server. A Localizer
+// returned by WithOverrides looks keys up in its Overrides and in the
+// translations it shares with the Localizer it was made from, which are never
+// copied. Overrides are safe for concurrent use, and their translations can
+// be replaced through Set while Localizers use them.
+type Overrides struct {
+	// Name identifies the overrides in errors, e.g. the ID of a tenant
+	Name string
+	mx   sync.RWMutex
+	// the translations by locale
+	messages map[string]map[string]string
+}
+
+// NewOverrides returns Overrides without translations.
+func NewOverrides(name string) *Overrides {
+	return &Overrides{Name: name, messages: make(map[string]map[string]string)}
+}
+
+// Set replaces the translations of locale in o with messages, keyed like the
+// translation files. An empty map removes them. References to glossary terms
+// are expanded with the terms defined in messages and those of SetGlossary,
+// but not those of translation files, which Localizers don't share. It fails
+// with a *FileError, leaving o as is, if a message refers to an undefined
+// term or its markup is malformed.
+func (o *Overrides) Set(locale string, messages map[string]string) error {
+	locale = strings.Replace(locale, "_", "-", -1)
+	m := make(map[string]string, len(messages))
+	for k, v := range messages {
+		m[k] = v
+	}
+	terms := extractGlossary(m, nil)
+	for _, k := range sortedKeys(m) {
+		s, err := expandGlossary(m[k], terms)
+		if err != nil {
+			return &FileError{Layer: o.Name, File: locale + ".json", Offset: -1, Err: fmt.Errorf("%s: %w", describeKey(k), err)}
+		}
+		m[k] = s
+	}
+	if err := validateMessages(locale+".json", m, nil); err != nil {
+		err.Layer = o.Name
+		return err
+	}
+	o.mx.Lock()
+	defer o.mx.Unlock()
+	if len(m) == 0 {
+		delete(o.messages, locale)
+	} else {
+		o.messages[locale] = m
+	}
+	return nil
+}
+
+// lookup returns the override of key in the first of locales which has one.
+// It may be called on nil Overrides.
+func (o *Overrides) lookup(locales []string, key string) (string, bool) {
+	if o == nil {
+		return "", false
+	}
+	o.mx.RLock()
+	defer o.mx.RUnlock()
+	for _, locale := range locales {
+		if s, found := o.messages[locale][key]; found {
+			return s, true
+		}
+	}
+	return "", false
+}
+
+// WithOverrides returns a Localizer translating like l, except for the keys
+// which o overrides. At each locale of the fallback chain, the override of a
+// key takes precedence over the translation of l, so the English override of
+// a key applies to a zh-CN Localizer without a Chinese translation of it. The
+// variants of a key in a register are overridden along with its value. Info,
+// Origin and the debug page describe the translations of l.
+func (l *Localizer) WithOverrides(o *Overrides) *Localizer {
+	c := *l
+	c.overrides = o
+	return &c
+}
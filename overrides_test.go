@@ -0,0 +1,81 @@
+package i18n
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOverrides(t *testing.T) {
+	base := &Localizer{
+		locale:    "zh-CN",
+		fallbacks: fallbackChain("zh-CN"),
+		messages: map[string]string{
+			"APP":                                  "蓝灯",
+			"WELCOME":                              "欢迎使用蓝灯, {0}",
+			registerKey("WELCOME", RegisterFormal): "您好, 欢迎使用蓝灯, {0}",
+			"HELP":                                 "帮助",
+			"ABOUT":                                "About Lantern",
+			"HI":                                   "欢迎",
+			registerKey("HI", RegisterFormal):      "Hi, Sir",
+		},
+		origins: map[string]string{
+			"APP":                                  "zh.json",
+			"WELCOME":                              "zh.json",
+			registerKey("WELCOME", RegisterFormal): "zh.json",
+			"HELP":                                 "zh.json",
+			"ABOUT":                                "en.json",
+			"HI":                                   "zh.json",
+			registerKey("HI", RegisterFormal):      "en.json",
+		},
+	}
+	acme := NewOverrides("acme")
+	tenant := base.WithOverrides(acme)
+	assert.Equal(t, "蓝灯", tenant.T("APP"), "should use the base without overrides")
+
+	assert.NoError(t, acme.Set("en", map[string]string{"APP": "Acme", "ABOUT": "About Acme"}))
+	assert.Equal(t, "蓝灯", tenant.T("APP"), "shouldn't prefer overrides of a fallback to the translation")
+	assert.Equal(t, "About Acme", tenant.T("ABOUT"), "should apply overrides of a fallback missing a translation")
+	assert.Equal(t, "About Lantern", base.T("ABOUT"))
+	assert.NoError(t, acme.Set("en", map[string]string{"APP": "Acme", "ABOUT": "About Acme", "HI": "Hi tenant"}))
+	assert.Equal(t, "欢迎", tenant.T("HI"))
+	assert.Equal(t, "欢迎", tenant.WithRegister(RegisterFormal).T("HI"), "shouldn't prefer the variant or overrides of a fallback to the translation")
+	assert.NoError(t, acme.Set("zh", map[string]string{"APP": "阿克米", "WELCOME": "欢迎使用阿克米, {0}"}))
+	assert.Equal(t, "阿克米", tenant.T("APP"))
+	assert.Equal(t, "欢迎使用阿克米, 伊娃", tenant.T("WELCOME", "伊娃"))
+	assert.Equal(t, "欢迎使用阿克米, 伊娃", tenant.WithRegister(RegisterFormal).T("WELCOME", "伊娃"), "should override the variants")
+	assert.Equal(t, "帮助", tenant.T("HELP"))
+	assert.Equal(t, "蓝灯", base.T("APP"), "shouldn't change the base")
+
+	assert.NoError(t, acme.Set("zh_CN", map[string]string{"APP": "阿克米中国"}))
+	assert.Equal(t, "阿克米中国", tenant.T("APP"), "should prefer the locale to its language")
+	base.origins["HELP"] = "zh-CN.json"
+	assert.NoError(t, acme.Set("zh", map[string]string{"APP": "阿克米", "HELP": "求助"}))
+	assert.Equal(t, "帮助", tenant.T("HELP"), "should prefer the translation of the locale to overrides of its language")
+	assert.NoError(t, acme.Set("zh-CN", nil))
+	assert.Equal(t, "阿克米", tenant.T("APP"), "should swap overrides in place")
+
+	SetGlossary(map[string]string{"APP_NAME": "Lantern"})
+	defer SetGlossary(nil)
+	assert.NoError(t, acme.Set("en", map[string]string{"{{BRAND}}": "Acme", "ABOUT": "About {{BRAND}}, powered by {{APP_NAME}}"}))
+	assert.Equal(t, "About Acme, powered by Lantern", tenant.T("ABOUT"), "should expand the glossary")
+	err := acme.Set("en", map[string]string{"ABOUT": "About {{BRAND}}"})
+	assert.EqualError(t, err, "acme:en.json: ABOUT: undefined glossary term {{BRAND}}")
+	assert.Equal(t, "About Acme, powered by Lantern", tenant.T("ABOUT"), "should keep the overrides on error")
+
+	err = acme.Set("zh", map[string]string{"APP": "<b><i>阿克米</b></i>"})
+	assert.EqualError(t, err, "acme:zh.json: APP: tag at offset 15: </b> crosses <i>")
+	assert.Equal(t, "阿克米", tenant.T("APP"), "should keep the overrides on error")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tenant.T("APP")
+			_ = acme.Set("zh", map[string]string{"APP": "阿克米"})
+		}()
+	}
+	wg.Wait()
+}
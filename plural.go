@@ -0,0 +1,100 @@
+package i18n
+
+import (
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// pluralForms are the CLDR plural forms of the languages whose plural
+// patterns don't just list one and other, in the order the patterns are
+// listed.
+var pluralForms = map[string][]plural.Form{
+	"ar": {plural.Zero, plural.One, plural.Two, plural.Few, plural.Many, plural.Other},
+	"cs": {plural.One, plural.Few, plural.Many, plural.Other},
+	"he": {plural.One, plural.Two, plural.Many, plural.Other},
+	"ja": {plural.Other},
+	"ko": {plural.Other},
+	"pl": {plural.One, plural.Few, plural.Many, plural.Other},
+	"ru": {plural.One, plural.Few, plural.Many, plural.Other},
+	"uk": {plural.One, plural.Few, plural.Many, plural.Other},
+	"vi": {plural.Other},
+	"zh": {plural.Other},
+}
+
+// pluralCategories are the names of the CLDR plural categories, as used by
+// the selectors of plural placeholders.
+var pluralCategories = map[plural.Form]string{
+	plural.Zero:  "zero",
+	plural.One:   "one",
+	plural.Two:   "two",
+	plural.Few:   "few",
+	plural.Many:  "many",
+	plural.Other: "other",
+}
+
+// isPluralSelector tells whether s selects a message of a plural
+// placeholder, as a plural category or as =n for the exact number n.
+func isPluralSelector(s string) bool {
+	if strings.HasPrefix(s, "=") {
+		_, ok := parseDecimal(s[1:])
+		return ok
+	}
+	for _, category := range pluralCategories {
+		if s == category {
+			return true
+		}
+	}
+	return false
+}
+
+// pluralPattern picks the pattern for the number d among patterns, which are
+// the patterns of the plural forms of the language of tag separated by |,
+// e.g. "{0} hour|{0} hours" in English. Missing patterns default to the last
+// one, so a single pattern is used for every number.
+func pluralPattern(tag language.Tag, patterns string, d Decimal) string {
+	choices := strings.Split(patterns, "|")
+	if len(choices) == 1 {
+		return choices[0]
+	}
+	base, _ := tag.Base()
+	forms, found := pluralForms[base.String()]
+	if !found {
+		forms = []plural.Form{plural.One, plural.Other}
+	}
+	form := pluralForm(tag, d)
+	for i, f := range forms {
+		if f == form && i < len(choices) {
+			return choices[i]
+		}
+	}
+	return choices[len(choices)-1]
+}
+
+// pluralForm returns the CLDR cardinal plural form of d as shown, i.e. with
+// its fraction digits, in the language of tag.
+func pluralForm(tag language.Tag, d Decimal) plural.Form {
+	// operands too large for an int may be passed modulo 10,000,000
+	i, _ := strconv.Atoi(lastDigits(d.integer, 7))
+	fraction := d.fraction
+	if len(fraction) > 7 {
+		fraction = fraction[:7]
+	}
+	f, _ := strconv.Atoi(fraction)
+	return plural.Cardinal.MatchPlural(tag, i, len(fraction), len(fraction), f, f)
+}
+
+func lastDigits(s string, n int) string {
+	if len(s) > n {
+		return s[len(s)-n:]
+	}
+	return s
+}
+
+// pluralizeInt picks the plural pattern of n and substitutes n, formatted for
+// tag, for {0}.
+func pluralizeInt(tag language.Tag, patterns string, n int64) string {
+	return strings.Replace(pluralPattern(tag, patterns, NewDecimal(n, 0)), "{0}", formatNumber(tag, n, "integer"), 1)
+}
@@ -0,0 +1,212 @@
+package i18n
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// WritePO writes c to w as a gettext PO file, with each key as the msgid of
+// an entry and its context as the msgctxt. Empty messages are written as
+// untranslated entries. The MessageInfo of keys is written in extracted
+// comments, as the description followed by "Max length: 20" and
+// "Screenshot: url" lines.
+func WritePO(w io.Writer, c *Catalog) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("msgid \"\"\nmsgstr \"\"\n")
+	writePOString(bw, "", "Language: "+c.Locale+"\n"+
+		"MIME-Version: 1.0\n"+
+		"Content-Type: text/plain; charset=UTF-8\n"+
+		"Content-Transfer-Encoding: 8bit\n")
+	for _, key := range sortedKeys(c.Messages) {
+		context, msgKey := SplitContextKey(key)
+		bw.WriteString("\n")
+		for _, comment := range poComments(c.Info[key]) {
+			bw.WriteString(strings.TrimRight("#. "+comment, " ") + "\n")
+		}
+		if context != "" {
+			writePOString(bw, "msgctxt ", context)
+		}
+		writePOString(bw, "msgid ", msgKey)
+		writePOString(bw, "msgstr ", c.Messages[key])
+	}
+	return bw.Flush()
+}
+
+// writePOString writes s as a quoted PO string after keyword, split after
+// each newline as gettext tools do.
+func writePOString(w *bufio.Writer, keyword string, s string) {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) > 1 && keyword != "" {
+		w.WriteString(keyword + "\"\"\n")
+		keyword = ""
+	}
+	if len(lines) == 0 {
+		lines = []string{""}
+	}
+	for _, line := range lines {
+		w.WriteString(keyword + quotePO(line) + "\n")
+	}
+}
+
+var poEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`, "\r", `\r`)
+
+func quotePO(s string) string {
+	return `"` + poEscaper.Replace(s) + `"`
+}
+
+// poEntry is an entry of a PO file being read.
+type poEntry struct {
+	context, id, str string
+	// the extracted comments
+	comments []string
+	// the keyword whose string continuation lines belong to
+	field *string
+	fuzzy bool
+	// whether the entry has a msgid, i.e. isn't just comments
+	hasID bool
+}
+
+// ReadPO reads a catalog from a gettext PO file written by WritePO or edited
+// by translators, taking the locale from the Language header. Untranslated
+// entries, fuzzy entries and obsolete ones are skipped, but the MessageInfo in
+// their extracted comments is kept. Plural forms aren't supported, messages
+// select plurals with {count, plural, ...} instead.
+func ReadPO(r io.Reader) (*Catalog, error) {
+	c := &Catalog{Messages: make(map[string]string)}
+	var e poEntry
+	end := func() {
+		switch {
+		case !e.hasID:
+		case e.id == "" && e.context == "":
+			c.Locale = poHeader(e.str, "Language")
+		default:
+			key := ContextKey(e.context, e.id)
+			if e.str != "" && !e.fuzzy {
+				c.Messages[key] = e.str
+			}
+			if info := parsePOComments(e.comments); info != (MessageInfo{}) {
+				if c.Info == nil {
+					c.Info = make(map[string]MessageInfo)
+				}
+				c.Info[key] = info
+			}
+		}
+		e = poEntry{}
+	}
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			if e.hasID {
+				end()
+			}
+			if strings.HasPrefix(line, "#,") && strings.Contains(line, "fuzzy") {
+				e.fuzzy = true
+			}
+			if strings.HasPrefix(line, "#.") {
+				e.comments = append(e.comments, strings.TrimPrefix(strings.TrimPrefix(line, "#."), " "))
+			}
+			continue
+		}
+		if line == "" {
+			end()
+			continue
+		}
+		keyword, value := line, ""
+		if i := strings.IndexAny(line, " \t"); i >= 0 {
+			keyword, value = line[:i], strings.TrimSpace(line[i:])
+		}
+		if strings.HasPrefix(line, `"`) {
+			keyword, value = "", line
+		}
+		switch keyword {
+		case "":
+			if e.field == nil {
+				return nil, fmt.Errorf("line %d: string without keyword", n)
+			}
+		case "msgctxt":
+			if e.hasID {
+				end()
+			}
+			e.field = &e.context
+		case "msgid":
+			if e.hasID {
+				end()
+			}
+			e.field, e.hasID = &e.id, true
+		case "msgstr":
+			e.field = &e.str
+		case "msgid_plural", "msgstr[0]":
+			return nil, fmt.Errorf("line %d: plural forms aren't supported", n)
+		default:
+			return nil, fmt.Errorf("line %d: unknown keyword %s", n, keyword)
+		}
+		s, err := strconv.Unquote(value)
+		if err != nil || !strings.HasPrefix(value, `"`) {
+			return nil, fmt.Errorf("line %d: malformed string %s", n, value)
+		}
+		*e.field += s
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	end()
+	if err := validateMessages(c.Locale+".po", c.Messages, c.Info); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+const (
+	poMaxLength  = "Max length: "
+	poScreenshot = "Screenshot: "
+)
+
+// poComments returns the extracted comments describing info.
+func poComments(info MessageInfo) []string {
+	var comments []string
+	if info.Description != "" {
+		comments = strings.Split(info.Description, "\n")
+	}
+	if info.MaxLength > 0 {
+		comments = append(comments, poMaxLength+strconv.Itoa(info.MaxLength))
+	}
+	if info.Screenshot != "" {
+		comments = append(comments, poScreenshot+info.Screenshot)
+	}
+	return comments
+}
+
+// parsePOComments parses the MessageInfo written by poComments.
+func parsePOComments(comments []string) MessageInfo {
+	var info MessageInfo
+	var description []string
+	for _, comment := range comments {
+		if n, err := strconv.Atoi(strings.TrimPrefix(comment, poMaxLength)); err == nil && strings.HasPrefix(comment, poMaxLength) {
+			info.MaxLength = n
+		} else if strings.HasPrefix(comment, poScreenshot) {
+			info.Screenshot = strings.TrimPrefix(comment, poScreenshot)
+		} else {
+			description = append(description, comment)
+		}
+	}
+	info.Description = strings.Join(description, "\n")
+	return info
+}
+
+// poHeader returns the value of the given field of the header entry of a PO
+// file.
+func poHeader(header string, field string) string {
+	for _, line := range strings.Split(header, "\n") {
+		if i := strings.IndexByte(line, ':'); i >= 0 && strings.TrimSpace(line[:i]) == field {
+			return strings.Replace(strings.TrimSpace(line[i+1:]), "_", "-", -1)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,88 @@
+package i18n
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPO(t *testing.T) {
+	c := &Catalog{Locale: "de", Messages: map[string]string{
+		"OPEN":         "Offen",
+		"menu\x04OPEN": "Öffnen",
+		"QUOTE":        `Sag "Hallo"`,
+		"LINES":        "Eins\nZwei",
+		"BLANK":        "",
+	}}
+	var buf bytes.Buffer
+	if !assert.NoError(t, WritePO(&buf, c)) {
+		return
+	}
+	po := buf.String()
+	assert.True(t, strings.HasPrefix(po, "msgid \"\"\nmsgstr \"\"\n\"Language: de\\n\"\n"))
+	assert.Contains(t, po, "\nmsgctxt \"menu\"\nmsgid \"OPEN\"\nmsgstr \"Öffnen\"\n")
+	assert.Contains(t, po, "\nmsgid \"QUOTE\"\nmsgstr \"Sag \\\"Hallo\\\"\"\n")
+	assert.Contains(t, po, "\nmsgid \"LINES\"\nmsgstr \"\"\n\"Eins\\n\"\n\"Zwei\"\n")
+	assert.Contains(t, po, "\nmsgid \"BLANK\"\nmsgstr \"\"\n")
+
+	read, err := ReadPO(&buf)
+	if assert.NoError(t, err) {
+		delete(c.Messages, "BLANK")
+		assert.Equal(t, c, read, "should round trip all but untranslated messages")
+	}
+
+	read, err = ReadPO(strings.NewReader(`# translator comment
+msgid ""
+msgstr "Language: pt_BR\n"
+
+#, fuzzy
+msgctxt "menu"
+msgid "OPEN"
+msgstr "Abrir?"
+
+#: app.go:12
+msgctxt "menu"
+msgid "SAVE"
+msgstr ""
+"Salvar"
+
+#~ msgid "OLD"
+#~ msgstr "Velho"
+msgid "CLOSE"
+msgstr "Fechar"
+`))
+	if assert.NoError(t, err) {
+		assert.Equal(t, &Catalog{Locale: "pt-BR", Messages: map[string]string{
+			"menu\x04SAVE": "Salvar",
+			"CLOSE":        "Fechar",
+		}}, read)
+	}
+
+	_, err = ReadPO(strings.NewReader("msgid \"FILES\"\nmsgid_plural \"FILES\"\nmsgstr[0] \"Datei\"\n"))
+	assert.EqualError(t, err, "line 2: plural forms aren't supported")
+	_, err = ReadPO(strings.NewReader("msgid \"OPEN\"\nmsgstr Offen\n"))
+	assert.EqualError(t, err, "line 2: malformed string Offen")
+	_, err = ReadPO(strings.NewReader("\"Offen\"\n"))
+	assert.EqualError(t, err, "line 1: string without keyword")
+}
+
+func TestPOMessageInfo(t *testing.T) {
+	c := &Catalog{Locale: "en", Messages: map[string]string{"SAVE": "Save", "OPEN": "Open"}, Info: map[string]MessageInfo{
+		"SAVE": {Description: "Button saving the form\n\nShown at the bottom", MaxLength: 8, Screenshot: "form.png"},
+	}}
+	var buf bytes.Buffer
+	if !assert.NoError(t, WritePO(&buf, c)) {
+		return
+	}
+	assert.Contains(t, buf.String(), "\n#. Button saving the form\n#.\n#. Shown at the bottom\n#. Max length: 8\n#. Screenshot: form.png\nmsgid \"SAVE\"\n")
+	read, err := ReadPO(&buf)
+	if assert.NoError(t, err) {
+		assert.Equal(t, c, read)
+	}
+
+	read, err = ReadPO(strings.NewReader("#. Max length: 3\nmsgid \"SAVE\"\nmsgstr \"\"\n\n#. Max length: 3\nmsgid \"OPEN\"\nmsgstr \"Open\"\n"))
+	assert.Nil(t, read)
+	assert.EqualError(t, err, ".po: OPEN: 4 characters, longer than the maximum of 3")
+}
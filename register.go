@@ -0,0 +1,140 @@
+package i18n
+
+import (
+	"strings"
+)
+
+// Register is the level of formality of translations, such as the choice
+// between "Sie" and "du" in German.
+type Register int
+
+const (
+	// RegisterDefault makes a Localizer use the register set through
+	// SetRegister.
+	RegisterDefault Register = iota
+	// RegisterNeutral uses the value of messages.
+	RegisterNeutral
+	// RegisterFormal uses the formal variant of messages, or else their
+	// value.
+	RegisterFormal
+	// RegisterInformal uses the informal variant of messages, or else their
+	// value.
+	RegisterInformal
+)
+
+// registerNames are the names of the registers of variants in translation
+// files.
+var registerNames = map[Register]string{
+	RegisterFormal:   "formal",
+	RegisterInformal: "informal",
+}
+
+func (r Register) String() string {
+	switch r {
+	case RegisterDefault:
+		return "default"
+	case RegisterNeutral:
+		return "neutral"
+	}
+	return registerNames[r]
+}
+
+// registerSeparator separates a key from the register of its variant in the
+// keys of merged translations.
+const registerSeparator = "\x1f"
+
+// currentRegister is the register set through SetRegister.
+var currentRegister = RegisterNeutral
+
+// SetRegister selects the formal or informal variant of messages which have
+// one, for the current locale and for Localizers using RegisterDefault.
+// Translation files supply variants in the formal and informal fields of
+// messages written as objects, e.g.
+//
+//   {"WELCOME": {"value": "Willkommen", "formal": "Willkommen, Sie", "informal": "Hallo du"}}
+//
+// A message without a variant in the register, in its locale or a more
+// specific one of the fallback chain, falls back to its value. It defaults to
+// RegisterNeutral.
+func SetRegister(r Register) {
+	if r == RegisterDefault {
+		r = RegisterNeutral
+	}
+	trMutex.Lock()
+	defer trMutex.Unlock()
+	currentRegister = r
+}
+
+// WithRegister returns a Localizer translating like l in the register r,
+// sharing the translations of l.
+func (l *Localizer) WithRegister(r Register) *Localizer {
+	c := *l
+	c.register = r
+	return &c
+}
+
+// Register returns the register of l, as given to WithRegister.
+func (l *Localizer) Register() Register {
+	return l.register
+}
+
+// registerKey returns the key under which the variant of the message key in
+// register r is merged.
+func registerKey(key string, r Register) string {
+	return key + registerSeparator + registerNames[r]
+}
+
+// splitRegisterKey splits a key returned by registerKey into the key of the
+// message and the register of the variant, which is RegisterNeutral for keys
+// of values.
+func splitRegisterKey(key string) (string, Register) {
+	i := strings.Index(key, registerSeparator)
+	if i < 0 {
+		return key, RegisterNeutral
+	}
+	name := key[i+len(registerSeparator):]
+	for r, n := range registerNames {
+		if n == name {
+			return key[:i], r
+		}
+	}
+	return key, RegisterNeutral
+}
+
+// lookup returns the translation of key in the register r.
+func (l *Localizer) lookup(key string, r Register) (string, bool) {
+	k := key
+	if r == RegisterFormal || r == RegisterInformal {
+		// the variant of a less specific locale doesn't replace the value
+		_, found := l.messages[key]
+		if _, variant := l.messages[registerKey(key, r)]; variant && (!found || l.rank(registerKey(key, r)) <= l.rank(key)) {
+			k = registerKey(key, r)
+		}
+	}
+	s, found := l.messages[k]
+	if l.overrides == nil {
+		return s, found
+	}
+	// overrides take precedence over the translation of the same locale,
+	// not over those of the locales before it in the fallback chain
+	locales := l.fallbacks
+	if found {
+		locales = l.fallbacks[:l.rank(k)+1]
+	}
+	if o, overridden := l.overrides.lookup(locales, key); overridden {
+		return o, true
+	}
+	return s, found
+}
+
+// rank returns the position in the fallback chain of the locale supplying
+// the message of k, which must be found.
+func (l *Localizer) rank(k string) int {
+	locale := originLocale(l.origins[k])
+	for i, fallback := range l.fallbacks {
+		if fallback == locale {
+			return i
+		}
+	}
+	return len(l.fallbacks) - 1
+}
@@ -0,0 +1,93 @@
+package i18n
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister(t *testing.T) {
+	defer restoreState()()
+	defer SetRegister(RegisterNeutral)
+	files := map[string]string{
+		"en.json":    `{"WELCOME": {"value": "Welcome, {0}", "formal": "Welcome, Sir"}, "HELP": {"value": "Help", "formal": "Help, Sir"}}`,
+		"de.json":    `{"WELCOME": {"value": "Willkommen, {0}", "formal": "Willkommen, {0}. Wie können wir Ihnen helfen?", "informal": "Hallo {0}!"}, "HELP": {"value": "Hilfe", "informal": "Hilf mir"}}`,
+		"de-AT.json": `{"WELCOME": "Servus, {0}"}`,
+		"fr.json":    `{"WELCOME": {"value": "Bienvenue", "formal": "Bienvenue, Madame, Monsieur, vous êtes très aimable", "maxLength": 12}}`,
+		"zh-CN.json": `{"WELCOME": "欢迎"}`,
+		"es.json":    `{"HELP": {"value": "Ayuda", "formal": "<b><i>Ay</b></i>", "maxLength": 10}}`,
+	}
+	SetMessagesFunc(func(path string) ([]byte, error) {
+		if s, found := files[path]; found {
+			return []byte(s), nil
+		}
+		return nil, os.ErrNotExist
+	})
+	if !assert.NoError(t, setLocale("de")) {
+		return
+	}
+	assert.Equal(t, "Willkommen, Eva", T("WELCOME", "Eva"))
+	SetRegister(RegisterInformal)
+	assert.Equal(t, "Hallo Eva!", T("WELCOME", "Eva"))
+	assert.Equal(t, "Hilf mir", T("HELP"))
+	SetRegister(RegisterFormal)
+	assert.Equal(t, "Willkommen, Eva. Wie können wir Ihnen helfen?", T("WELCOME", "Eva"))
+	assert.Equal(t, "Hilfe", T("HELP"), "should fall back to the value")
+	SetRegister(RegisterDefault)
+	assert.Equal(t, "Willkommen, Eva", T("WELCOME", "Eva"), "should reset to neutral")
+
+	at, err := NewLocalizer("de-AT")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, RegisterDefault, at.Register())
+	assert.Equal(t, "Servus, Eva", at.T("WELCOME", "Eva"))
+	informal := at.WithRegister(RegisterInformal)
+	assert.Equal(t, RegisterInformal, informal.Register())
+	assert.Equal(t, "Hilf mir", informal.T("HELP"), "should search the fallback chain for the variant")
+	assert.Equal(t, "Servus, Eva", informal.T("WELCOME", "Eva"), "shouldn't take the variant of a less specific locale")
+	assert.Equal(t, "Hilfe", at.T("HELP"), "shouldn't change the original Localizer")
+	SetRegister(RegisterInformal)
+	assert.Equal(t, "Hilfe", at.WithRegister(RegisterNeutral).T("HELP"), "should override SetRegister")
+	SetRegister(RegisterNeutral)
+
+	// variants of English don't apply to a translated value
+	zh, err := NewLocalizer("zh-CN")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "欢迎", zh.WithRegister(RegisterFormal).T("WELCOME"))
+		assert.Equal(t, "Help, Sir", zh.WithRegister(RegisterFormal).T("HELP"))
+	}
+
+	_, err = NewLocalizer("fr")
+	var loadErr *LoadError
+	if assert.True(t, errors.As(err, &loadErr)) && assert.Len(t, loadErr.Files, 1) {
+		assert.EqualError(t, loadErr.Files[0], "fr.json: WELCOME (formal): 51 characters, longer than the maximum of 12")
+	}
+	_, err = NewLocalizer("es")
+	assert.EqualError(t, err, "Error loading locale es: es.json: HELP (formal): tag at offset 8: </b> crosses <i>")
+
+	c, err := LoadCatalog("de")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, map[string]map[Register]string{
+		"WELCOME": {RegisterFormal: "Willkommen, {0}. Wie können wir Ihnen helfen?", RegisterInformal: "Hallo {0}!"},
+		"HELP":    {RegisterInformal: "Hilf mir"},
+	}, c.Variants)
+	var buf bytes.Buffer
+	if assert.NoError(t, WriteJSON(&buf, c)) {
+		assert.Contains(t, buf.String(), `
+  "HELP": {
+    "value": "Hilfe",
+    "informal": "Hilf mir"
+  },`)
+	}
+	read, err := ReadJSON(&buf, "de")
+	if assert.NoError(t, err) {
+		assert.Equal(t, c, read)
+	}
+	assert.Equal(t, "formal", RegisterFormal.String())
+}
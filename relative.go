@@ -0,0 +1,633 @@
+package i18n
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// RelativeStyle selects how RelativeTime phrases a time.
+type RelativeStyle int
+
+const (
+	// RelativeAuto uses words such as "yesterday" or "now" where the locale
+	// has them, and numbers otherwise.
+	RelativeAuto RelativeStyle = iota
+	// RelativeNumeric always uses numbers, e.g. "1 day ago" or "in 0 seconds".
+	RelativeNumeric
+)
+
+// relativeData holds the CLDR relative time and duration patterns of a
+// language, listed by unit, e.g. "hour", as plural patterns.
+type relativeData struct {
+	// future and past patterns, e.g. "in {0} hour|in {0} hours"
+	future, past map[string]string
+	// the words used for some offsets by RelativeAuto, keyed by unit and
+	// offset, e.g. "day-1" for yesterday
+	words map[string]string
+	// short patterns of the units of durations, e.g. "{0} hr"
+	duration map[string]string
+	// durationSep joins the units of a duration
+	durationSep string
+}
+
+var relativeUnits = []struct {
+	name string
+	// the unit and the limit below which it's used, in seconds
+	size, limit float64
+}{
+	{"second", 1, 45},
+	{"minute", 60, 45 * 60},
+	{"hour", 3600, 22 * 3600},
+	{"day", 86400, 7 * 86400},
+	{"week", 7 * 86400, 30 * 86400},
+	{"month", 30.436875 * 86400, 335 * 86400},
+	{"year", 365.2425 * 86400, math.Inf(1)},
+}
+
+var relativeLanguages = map[string]*relativeData{
+	"ar": {
+		future: map[string]string{
+			"second": "خلال {0} ثانية|خلال ثانية واحدة|خلال ثانيتين|خلال {0} ثوانٍ|خلال {0} ثانية|خلال {0} ثانية",
+			"minute": "خلال {0} دقيقة|خلال دقيقة واحدة|خلال دقيقتين|خلال {0} دقائق|خلال {0} دقيقة|خلال {0} دقيقة",
+			"hour":   "خلال {0} ساعة|خلال ساعة واحدة|خلال ساعتين|خلال {0} ساعات|خلال {0} ساعة|خلال {0} ساعة",
+			"day":    "خلال {0} يوم|خلال يوم واحد|خلال يومين|خلال {0} أيام|خلال {0} يومًا|خلال {0} يوم",
+			"week":   "خلال {0} أسبوع|خلال أسبوع واحد|خلال أسبوعين|خلال {0} أسابيع|خلال {0} أسبوعًا|خلال {0} أسبوع",
+			"month":  "خلال {0} شهر|خلال شهر واحد|خلال شهرين|خلال {0} أشهر|خلال {0} شهرًا|خلال {0} شهر",
+			"year":   "خلال {0} سنة|خلال سنة واحدة|خلال سنتين|خلال {0} سنوات|خلال {0} سنة|خلال {0} سنة",
+		},
+		past: map[string]string{
+			"second": "قبل {0} ثانية|قبل ثانية واحدة|قبل ثانيتين|قبل {0} ثوانٍ|قبل {0} ثانية|قبل {0} ثانية",
+			"minute": "قبل {0} دقيقة|قبل دقيقة واحدة|قبل دقيقتين|قبل {0} دقائق|قبل {0} دقيقة|قبل {0} دقيقة",
+			"hour":   "قبل {0} ساعة|قبل ساعة واحدة|قبل ساعتين|قبل {0} ساعات|قبل {0} ساعة|قبل {0} ساعة",
+			"day":    "قبل {0} يوم|قبل يوم واحد|قبل يومين|قبل {0} أيام|قبل {0} يومًا|قبل {0} يوم",
+			"week":   "قبل {0} أسبوع|قبل أسبوع واحد|قبل أسبوعين|قبل {0} أسابيع|قبل {0} أسبوعًا|قبل {0} أسبوع",
+			"month":  "قبل {0} شهر|قبل شهر واحد|قبل شهرين|قبل {0} أشهر|قبل {0} شهرًا|قبل {0} شهر",
+			"year":   "قبل {0} سنة|قبل سنة واحدة|قبل سنتين|قبل {0} سنوات|قبل {0} سنة|قبل {0} سنة",
+		},
+		words: map[string]string{
+			"second0": "الآن", "day-2": "أول أمس", "day-1": "أمس", "day0": "اليوم", "day1": "غدًا", "day2": "بعد الغد",
+			"week-1": "الأسبوع الماضي", "week1": "الأسبوع القادم", "month-1": "الشهر الماضي", "month1": "الشهر القادم",
+			"year-1": "السنة الماضية", "year1": "السنة القادمة",
+		},
+		duration:    map[string]string{"second": "{0} ث", "minute": "{0} د", "hour": "{0} س", "day": "{0} يوم|{0} يوم|{0} يوم|{0} أيام|{0} يوم"},
+		durationSep: "، ",
+	},
+	"cs": {
+		future: map[string]string{
+			"second": "za {0} sekundu|za {0} sekundy|za {0} sekundy|za {0} sekund",
+			"minute": "za {0} minutu|za {0} minuty|za {0} minuty|za {0} minut",
+			"hour":   "za {0} hodinu|za {0} hodiny|za {0} hodiny|za {0} hodin",
+			"day":    "za {0} den|za {0} dny|za {0} dne|za {0} dní",
+			"week":   "za {0} týden|za {0} týdny|za {0} týdne|za {0} týdnů",
+			"month":  "za {0} měsíc|za {0} měsíce|za {0} měsíce|za {0} měsíců",
+			"year":   "za {0} rok|za {0} roky|za {0} roku|za {0} let",
+		},
+		past: map[string]string{
+			"second": "před {0} sekundou|před {0} sekundami|před {0} sekundy|před {0} sekundami",
+			"minute": "před {0} minutou|před {0} minutami|před {0} minuty|před {0} minutami",
+			"hour":   "před {0} hodinou|před {0} hodinami|před {0} hodiny|před {0} hodinami",
+			"day":    "před {0} dnem|před {0} dny|před {0} dne|před {0} dny",
+			"week":   "před {0} týdnem|před {0} týdny|před {0} týdne|před {0} týdny",
+			"month":  "před {0} měsícem|před {0} měsíci|před {0} měsíce|před {0} měsíci",
+			"year":   "před {0} rokem|před {0} lety|před {0} roku|před {0} lety",
+		},
+		words: map[string]string{
+			"second0": "nyní", "day-2": "předevčírem", "day-1": "včera", "day0": "dnes", "day1": "zítra", "day2": "pozítří",
+			"week-1": "minulý týden", "week1": "příští týden", "month-1": "minulý měsíc", "month1": "příští měsíc",
+			"year-1": "minulý rok", "year1": "příští rok",
+		},
+		duration:    map[string]string{"second": "{0} s", "minute": "{0} min", "hour": "{0} h", "day": "{0} den|{0} dny|{0} dne|{0} dní"},
+		durationSep: " ",
+	},
+	"da": {
+		future: map[string]string{
+			"second": "om {0} sekund|om {0} sekunder", "minute": "om {0} minut|om {0} minutter", "hour": "om {0} time|om {0} timer",
+			"day": "om {0} dag|om {0} dage", "week": "om {0} uge|om {0} uger", "month": "om {0} måned|om {0} måneder", "year": "om {0} år",
+		},
+		past: map[string]string{
+			"second": "for {0} sekund siden|for {0} sekunder siden", "minute": "for {0} minut siden|for {0} minutter siden",
+			"hour": "for {0} time siden|for {0} timer siden", "day": "for {0} dag siden|for {0} dage siden",
+			"week": "for {0} uge siden|for {0} uger siden", "month": "for {0} måned siden|for {0} måneder siden",
+			"year": "for {0} år siden",
+		},
+		words: map[string]string{
+			"second0": "nu", "day-2": "i forgårs", "day-1": "i går", "day0": "i dag", "day1": "i morgen", "day2": "i overmorgen",
+			"week-1": "sidste uge", "week1": "næste uge", "month-1": "sidste måned", "month1": "næste måned",
+			"year-1": "sidste år", "year1": "næste år",
+		},
+		duration:    map[string]string{"second": "{0} sek.", "minute": "{0} min.", "hour": "{0} t.", "day": "{0} dag|{0} dage"},
+		durationSep: " ",
+	},
+	"de": {
+		future: map[string]string{
+			"second": "in {0} Sekunde|in {0} Sekunden", "minute": "in {0} Minute|in {0} Minuten", "hour": "in {0} Stunde|in {0} Stunden",
+			"day": "in {0} Tag|in {0} Tagen", "week": "in {0} Woche|in {0} Wochen", "month": "in {0} Monat|in {0} Monaten", "year": "in {0} Jahr|in {0} Jahren",
+		},
+		past: map[string]string{
+			"second": "vor {0} Sekunde|vor {0} Sekunden", "minute": "vor {0} Minute|vor {0} Minuten", "hour": "vor {0} Stunde|vor {0} Stunden",
+			"day": "vor {0} Tag|vor {0} Tagen", "week": "vor {0} Woche|vor {0} Wochen", "month": "vor {0} Monat|vor {0} Monaten", "year": "vor {0} Jahr|vor {0} Jahren",
+		},
+		words: map[string]string{
+			"second0": "jetzt", "day-2": "vorgestern", "day-1": "gestern", "day0": "heute", "day1": "morgen", "day2": "übermorgen",
+			"week-1": "letzte Woche", "week1": "nächste Woche", "month-1": "letzten Monat", "month1": "nächsten Monat",
+			"year-1": "letztes Jahr", "year1": "nächstes Jahr",
+		},
+		duration:    map[string]string{"second": "{0} Sek.", "minute": "{0} Min.", "hour": "{0} Std.", "day": "{0} Tg."},
+		durationSep: " ",
+	},
+	"en": {
+		future: map[string]string{
+			"second": "in {0} second|in {0} seconds", "minute": "in {0} minute|in {0} minutes", "hour": "in {0} hour|in {0} hours",
+			"day": "in {0} day|in {0} days", "week": "in {0} week|in {0} weeks", "month": "in {0} month|in {0} months", "year": "in {0} year|in {0} years",
+		},
+		past: map[string]string{
+			"second": "{0} second ago|{0} seconds ago", "minute": "{0} minute ago|{0} minutes ago", "hour": "{0} hour ago|{0} hours ago",
+			"day": "{0} day ago|{0} days ago", "week": "{0} week ago|{0} weeks ago", "month": "{0} month ago|{0} months ago", "year": "{0} year ago|{0} years ago",
+		},
+		words: map[string]string{
+			"second0": "now", "day-1": "yesterday", "day0": "today", "day1": "tomorrow",
+			"week-1": "last week", "week1": "next week", "month-1": "last month", "month1": "next month",
+			"year-1": "last year", "year1": "next year",
+		},
+		duration:    map[string]string{"second": "{0} sec", "minute": "{0} min", "hour": "{0} hr", "day": "{0} day|{0} days"},
+		durationSep: " ",
+	},
+	"es": {
+		future: map[string]string{
+			"second": "dentro de {0} segundo|dentro de {0} segundos", "minute": "dentro de {0} minuto|dentro de {0} minutos",
+			"hour": "dentro de {0} hora|dentro de {0} horas", "day": "dentro de {0} día|dentro de {0} días",
+			"week": "dentro de {0} semana|dentro de {0} semanas", "month": "dentro de {0} mes|dentro de {0} meses",
+			"year": "dentro de {0} año|dentro de {0} años",
+		},
+		past: map[string]string{
+			"second": "hace {0} segundo|hace {0} segundos", "minute": "hace {0} minuto|hace {0} minutos", "hour": "hace {0} hora|hace {0} horas",
+			"day": "hace {0} día|hace {0} días", "week": "hace {0} semana|hace {0} semanas", "month": "hace {0} mes|hace {0} meses",
+			"year": "hace {0} año|hace {0} años",
+		},
+		words: map[string]string{
+			"second0": "ahora", "day-2": "anteayer", "day-1": "ayer", "day0": "hoy", "day1": "mañana", "day2": "pasado mañana",
+			"week-1": "la semana pasada", "week1": "la próxima semana", "month-1": "el mes pasado", "month1": "el próximo mes",
+			"year-1": "el año pasado", "year1": "el próximo año",
+		},
+		duration:    map[string]string{"second": "{0} s", "minute": "{0} min", "hour": "{0} h", "day": "{0} d"},
+		durationSep: " ",
+	},
+	"fa": {
+		future: map[string]string{
+			"second": "{0} ثانیه بعد", "minute": "{0} دقیقه بعد", "hour": "{0} ساعت بعد", "day": "{0} روز بعد",
+			"week": "{0} هفته بعد", "month": "{0} ماه بعد", "year": "{0} سال بعد",
+		},
+		past: map[string]string{
+			"second": "{0} ثانیه پیش", "minute": "{0} دقیقه پیش", "hour": "{0} ساعت پیش", "day": "{0} روز پیش",
+			"week": "{0} هفته پیش", "month": "{0} ماه پیش", "year": "{0} سال پیش",
+		},
+		words: map[string]string{
+			"second0": "اکنون", "day-2": "پریروز", "day-1": "دیروز", "day0": "امروز", "day1": "فردا", "day2": "پس‌فردا",
+			"week-1": "هفتهٔ گذشته", "week1": "هفتهٔ آینده", "month-1": "ماه گذشته", "month1": "ماه آینده",
+			"year-1": "سال گذشته", "year1": "سال آینده",
+		},
+		duration:    map[string]string{"second": "{0} ثانیه", "minute": "{0} دقیقه", "hour": "{0} ساعت", "day": "{0} روز"},
+		durationSep: "، ",
+	},
+	"fi": {
+		future: map[string]string{
+			"second": "{0} sekunnin päästä", "minute": "{0} minuutin päästä", "hour": "{0} tunnin päästä", "day": "{0} päivän päästä",
+			"week": "{0} viikon päästä", "month": "{0} kuukauden päästä", "year": "{0} vuoden päästä",
+		},
+		past: map[string]string{
+			"second": "{0} sekunti sitten|{0} sekuntia sitten", "minute": "{0} minuutti sitten|{0} minuuttia sitten",
+			"hour": "{0} tunti sitten|{0} tuntia sitten", "day": "{0} päivä sitten|{0} päivää sitten",
+			"week": "{0} viikko sitten|{0} viikkoa sitten", "month": "{0} kuukausi sitten|{0} kuukautta sitten",
+			"year": "{0} vuosi sitten|{0} vuotta sitten",
+		},
+		words: map[string]string{
+			"second0": "nyt", "day-2": "toissa päivänä", "day-1": "eilen", "day0": "tänään", "day1": "huomenna", "day2": "ylihuomenna",
+			"week-1": "viime viikolla", "week1": "ensi viikolla", "month-1": "viime kuussa", "month1": "ensi kuussa",
+			"year-1": "viime vuonna", "year1": "ensi vuonna",
+		},
+		duration:    map[string]string{"second": "{0} s", "minute": "{0} min", "hour": "{0} t", "day": "{0} pv"},
+		durationSep: " ",
+	},
+	"fr": {
+		future: map[string]string{
+			"second": "dans {0} seconde|dans {0} secondes", "minute": "dans {0} minute|dans {0} minutes", "hour": "dans {0} heure|dans {0} heures",
+			"day": "dans {0} jour|dans {0} jours", "week": "dans {0} semaine|dans {0} semaines", "month": "dans {0} mois",
+			"year": "dans {0} an|dans {0} ans",
+		},
+		past: map[string]string{
+			"second": "il y a {0} seconde|il y a {0} secondes", "minute": "il y a {0} minute|il y a {0} minutes",
+			"hour": "il y a {0} heure|il y a {0} heures", "day": "il y a {0} jour|il y a {0} jours",
+			"week": "il y a {0} semaine|il y a {0} semaines", "month": "il y a {0} mois", "year": "il y a {0} an|il y a {0} ans",
+		},
+		words: map[string]string{
+			"second0": "maintenant", "day-2": "avant-hier", "day-1": "hier", "day0": "aujourd’hui", "day1": "demain", "day2": "après-demain",
+			"week-1": "la semaine dernière", "week1": "la semaine prochaine", "month-1": "le mois dernier", "month1": "le mois prochain",
+			"year-1": "l’année dernière", "year1": "l’année prochaine",
+		},
+		duration:    map[string]string{"second": "{0} s", "minute": "{0} min", "hour": "{0} h", "day": "{0} j"},
+		durationSep: " ",
+	},
+	"he": {
+		future: map[string]string{
+			"second": "בעוד שנייה|בעוד שתי שניות|בעוד {0} שניות|בעוד {0} שניות",
+			"minute": "בעוד דקה|בעוד שתי דקות|בעוד {0} דקות|בעוד {0} דקות",
+			"hour":   "בעוד שעה|בעוד שעתיים|בעוד {0} שעות|בעוד {0} שעות",
+			"day":    "בעוד יום|בעוד יומיים|בעוד {0} ימים|בעוד {0} ימים",
+			"week":   "בעוד שבוע|בעוד שבועיים|בעוד {0} שבועות|בעוד {0} שבועות",
+			"month":  "בעוד חודש|בעוד חודשיים|בעוד {0} חודשים|בעוד {0} חודשים",
+			"year":   "בעוד שנה|בעוד שנתיים|בעוד {0} שנים|בעוד {0} שנים",
+		},
+		past: map[string]string{
+			"second": "לפני שנייה|לפני שתי שניות|לפני {0} שניות|לפני {0} שניות",
+			"minute": "לפני דקה|לפני שתי דקות|לפני {0} דקות|לפני {0} דקות",
+			"hour":   "לפני שעה|לפני שעתיים|לפני {0} שעות|לפני {0} שעות",
+			"day":    "לפני יום|לפני יומיים|לפני {0} ימים|לפני {0} ימים",
+			"week":   "לפני שבוע|לפני שבועיים|לפני {0} שבועות|לפני {0} שבועות",
+			"month":  "לפני חודש|לפני חודשיים|לפני {0} חודשים|לפני {0} חודשים",
+			"year":   "לפני שנה|לפני שנתיים|לפני {0} שנים|לפני {0} שנים",
+		},
+		words: map[string]string{
+			"second0": "עכשיו", "day-2": "שלשום", "day-1": "אתמול", "day0": "היום", "day1": "מחר", "day2": "מחרתיים",
+			"week-1": "השבוע שעבר", "week1": "השבוע הבא", "month-1": "החודש שעבר", "month1": "החודש הבא",
+			"year-1": "השנה שעברה", "year1": "השנה הבאה",
+		},
+		duration:    map[string]string{"second": "{0} שנ׳", "minute": "{0} דק׳", "hour": "{0} שע׳", "day": "יום {0}|יומיים|{0} ימ׳|{0} ימ׳"},
+		durationSep: " ",
+	},
+	"hi": {
+		future: map[string]string{
+			"second": "{0} सेकंड में", "minute": "{0} मिनट में", "hour": "{0} घंटे में", "day": "{0} दिन में",
+			"week": "{0} सप्ताह में", "month": "{0} माह में", "year": "{0} वर्ष में",
+		},
+		past: map[string]string{
+			"second": "{0} सेकंड पहले", "minute": "{0} मिनट पहले", "hour": "{0} घंटे पहले", "day": "{0} दिन पहले",
+			"week": "{0} सप्ताह पहले", "month": "{0} माह पहले", "year": "{0} वर्ष पहले",
+		},
+		words: map[string]string{
+			"second0": "अब", "day-2": "परसों", "day-1": "कल", "day0": "आज", "day1": "कल", "day2": "परसों",
+			"week-1": "पिछला सप्ताह", "week1": "अगला सप्ताह", "month-1": "पिछला माह", "month1": "अगला माह",
+			"year-1": "पिछला वर्ष", "year1": "अगला वर्ष",
+		},
+		duration:    map[string]string{"second": "{0} से॰", "minute": "{0} मि॰", "hour": "{0} घं॰", "day": "{0} दिन"},
+		durationSep: " ",
+	},
+	"it": {
+		future: map[string]string{
+			"second": "tra {0} secondo|tra {0} secondi", "minute": "tra {0} minuto|tra {0} minuti", "hour": "tra {0} ora|tra {0} ore",
+			"day": "tra {0} giorno|tra {0} giorni", "week": "tra {0} settimana|tra {0} settimane", "month": "tra {0} mese|tra {0} mesi",
+			"year": "tra {0} anno|tra {0} anni",
+		},
+		past: map[string]string{
+			"second": "{0} secondo fa|{0} secondi fa", "minute": "{0} minuto fa|{0} minuti fa", "hour": "{0} ora fa|{0} ore fa",
+			"day": "{0} giorno fa|{0} giorni fa", "week": "{0} settimana fa|{0} settimane fa", "month": "{0} mese fa|{0} mesi fa",
+			"year": "{0} anno fa|{0} anni fa",
+		},
+		words: map[string]string{
+			"second0": "ora", "day-2": "l’altro ieri", "day-1": "ieri", "day0": "oggi", "day1": "domani", "day2": "dopodomani",
+			"week-1": "settimana scorsa", "week1": "settimana prossima", "month-1": "mese scorso", "month1": "mese prossimo",
+			"year-1": "anno scorso", "year1": "anno prossimo",
+		},
+		duration:    map[string]string{"second": "{0} s", "minute": "{0} min", "hour": "{0} h", "day": "{0} g"},
+		durationSep: " ",
+	},
+	"ja": {
+		future: map[string]string{
+			"second": "{0} 秒後", "minute": "{0} 分後", "hour": "{0} 時間後", "day": "{0} 日後",
+			"week": "{0} 週間後", "month": "{0} か月後", "year": "{0} 年後",
+		},
+		past: map[string]string{
+			"second": "{0} 秒前", "minute": "{0} 分前", "hour": "{0} 時間前", "day": "{0} 日前",
+			"week": "{0} 週間前", "month": "{0} か月前", "year": "{0} 年前",
+		},
+		words: map[string]string{
+			"second0": "今", "day-2": "一昨日", "day-1": "昨日", "day0": "今日", "day1": "明日", "day2": "明後日",
+			"week-1": "先週", "week1": "来週", "month-1": "先月", "month1": "来月", "year-1": "昨年", "year1": "来年",
+		},
+		duration:    map[string]string{"second": "{0} 秒", "minute": "{0} 分", "hour": "{0} 時間", "day": "{0} 日"},
+		durationSep: " ",
+	},
+	"ko": {
+		future: map[string]string{
+			"second": "{0}초 후", "minute": "{0}분 후", "hour": "{0}시간 후", "day": "{0}일 후",
+			"week": "{0}주 후", "month": "{0}개월 후", "year": "{0}년 후",
+		},
+		past: map[string]string{
+			"second": "{0}초 전", "minute": "{0}분 전", "hour": "{0}시간 전", "day": "{0}일 전",
+			"week": "{0}주 전", "month": "{0}개월 전", "year": "{0}년 전",
+		},
+		words: map[string]string{
+			"second0": "지금", "day-2": "그저께", "day-1": "어제", "day0": "오늘", "day1": "내일", "day2": "모레",
+			"week-1": "지난주", "week1": "다음 주", "month-1": "지난달", "month1": "다음 달", "year-1": "작년", "year1": "내년",
+		},
+		duration:    map[string]string{"second": "{0}초", "minute": "{0}분", "hour": "{0}시간", "day": "{0}일"},
+		durationSep: " ",
+	},
+	"nb": {
+		future: map[string]string{
+			"second": "om {0} sekund|om {0} sekunder", "minute": "om {0} minutt|om {0} minutter", "hour": "om {0} time|om {0} timer",
+			"day": "om {0} døgn", "week": "om {0} uke|om {0} uker", "month": "om {0} måned|om {0} måneder", "year": "om {0} år",
+		},
+		past: map[string]string{
+			"second": "for {0} sekund siden|for {0} sekunder siden", "minute": "for {0} minutt siden|for {0} minutter siden",
+			"hour": "for {0} time siden|for {0} timer siden", "day": "for {0} døgn siden",
+			"week": "for {0} uke siden|for {0} uker siden", "month": "for {0} måned siden|for {0} måneder siden",
+			"year": "for {0} år siden",
+		},
+		words: map[string]string{
+			"second0": "nå", "day-2": "i forgårs", "day-1": "i går", "day0": "i dag", "day1": "i morgen", "day2": "i overmorgen",
+			"week-1": "forrige uke", "week1": "neste uke", "month-1": "forrige måned", "month1": "neste måned",
+			"year-1": "i fjor", "year1": "neste år",
+		},
+		duration:    map[string]string{"second": "{0} sek", "minute": "{0} min", "hour": "{0} t", "day": "{0} d"},
+		durationSep: " ",
+	},
+	"nl": {
+		future: map[string]string{
+			"second": "over {0} seconde|over {0} seconden", "minute": "over {0} minuut|over {0} minuten", "hour": "over {0} uur",
+			"day": "over {0} dag|over {0} dagen", "week": "over {0} week|over {0} weken", "month": "over {0} maand|over {0} maanden",
+			"year": "over {0} jaar",
+		},
+		past: map[string]string{
+			"second": "{0} seconde geleden|{0} seconden geleden", "minute": "{0} minuut geleden|{0} minuten geleden", "hour": "{0} uur geleden",
+			"day": "{0} dag geleden|{0} dagen geleden", "week": "{0} week geleden|{0} weken geleden",
+			"month": "{0} maand geleden|{0} maanden geleden", "year": "{0} jaar geleden",
+		},
+		words: map[string]string{
+			"second0": "nu", "day-2": "eergisteren", "day-1": "gisteren", "day0": "vandaag", "day1": "morgen", "day2": "overmorgen",
+			"week-1": "vorige week", "week1": "volgende week", "month-1": "vorige maand", "month1": "volgende maand",
+			"year-1": "vorig jaar", "year1": "volgend jaar",
+		},
+		duration:    map[string]string{"second": "{0} sec", "minute": "{0} min", "hour": "{0} uur", "day": "{0} dag|{0} dagen"},
+		durationSep: " ",
+	},
+	"pl": {
+		future: map[string]string{
+			"second": "za {0} sekundę|za {0} sekundy|za {0} sekund|za {0} sekundy",
+			"minute": "za {0} minutę|za {0} minuty|za {0} minut|za {0} minuty",
+			"hour":   "za {0} godzinę|za {0} godziny|za {0} godzin|za {0} godziny",
+			"day":    "za {0} dzień|za {0} dni|za {0} dni|za {0} dnia",
+			"week":   "za {0} tydzień|za {0} tygodnie|za {0} tygodni|za {0} tygodnia",
+			"month":  "za {0} miesiąc|za {0} miesiące|za {0} miesięcy|za {0} miesiąca",
+			"year":   "za {0} rok|za {0} lata|za {0} lat|za {0} roku",
+		},
+		past: map[string]string{
+			"second": "{0} sekundę temu|{0} sekundy temu|{0} sekund temu|{0} sekundy temu",
+			"minute": "{0} minutę temu|{0} minuty temu|{0} minut temu|{0} minuty temu",
+			"hour":   "{0} godzinę temu|{0} godziny temu|{0} godzin temu|{0} godziny temu",
+			"day":    "{0} dzień temu|{0} dni temu|{0} dni temu|{0} dnia temu",
+			"week":   "{0} tydzień temu|{0} tygodnie temu|{0} tygodni temu|{0} tygodnia temu",
+			"month":  "{0} miesiąc temu|{0} miesiące temu|{0} miesięcy temu|{0} miesiąca temu",
+			"year":   "{0} rok temu|{0} lata temu|{0} lat temu|{0} roku temu",
+		},
+		words: map[string]string{
+			"second0": "teraz", "day-2": "przedwczoraj", "day-1": "wczoraj", "day0": "dzisiaj", "day1": "jutro", "day2": "pojutrze",
+			"week-1": "w zeszłym tygodniu", "week1": "w przyszłym tygodniu", "month-1": "w zeszłym miesiącu", "month1": "w przyszłym miesiącu",
+			"year-1": "w zeszłym roku", "year1": "w przyszłym roku",
+		},
+		duration:    map[string]string{"second": "{0} s", "minute": "{0} min", "hour": "{0} godz.", "day": "{0} dzień|{0} dni|{0} dni|{0} dnia"},
+		durationSep: " ",
+	},
+	"pt": {
+		future: map[string]string{
+			"second": "em {0} segundo|em {0} segundos", "minute": "em {0} minuto|em {0} minutos", "hour": "em {0} hora|em {0} horas",
+			"day": "em {0} dia|em {0} dias", "week": "em {0} semana|em {0} semanas", "month": "em {0} mês|em {0} meses",
+			"year": "em {0} ano|em {0} anos",
+		},
+		past: map[string]string{
+			"second": "há {0} segundo|há {0} segundos", "minute": "há {0} minuto|há {0} minutos", "hour": "há {0} hora|há {0} horas",
+			"day": "há {0} dia|há {0} dias", "week": "há {0} semana|há {0} semanas", "month": "há {0} mês|há {0} meses",
+			"year": "há {0} ano|há {0} anos",
+		},
+		words: map[string]string{
+			"second0": "agora", "day-2": "anteontem", "day-1": "ontem", "day0": "hoje", "day1": "amanhã", "day2": "depois de amanhã",
+			"week-1": "semana passada", "week1": "próxima semana", "month-1": "mês passado", "month1": "próximo mês",
+			"year-1": "ano passado", "year1": "próximo ano",
+		},
+		duration:    map[string]string{"second": "{0} s", "minute": "{0} min", "hour": "{0} h", "day": "{0} dia|{0} dias"},
+		durationSep: " ",
+	},
+	"ru": {
+		future: map[string]string{
+			"second": "через {0} секунду|через {0} секунды|через {0} секунд|через {0} секунды",
+			"minute": "через {0} минуту|через {0} минуты|через {0} минут|через {0} минуты",
+			"hour":   "через {0} час|через {0} часа|через {0} часов|через {0} часа",
+			"day":    "через {0} день|через {0} дня|через {0} дней|через {0} дня",
+			"week":   "через {0} неделю|через {0} недели|через {0} недель|через {0} недели",
+			"month":  "через {0} месяц|через {0} месяца|через {0} месяцев|через {0} месяца",
+			"year":   "через {0} год|через {0} года|через {0} лет|через {0} года",
+		},
+		past: map[string]string{
+			"second": "{0} секунду назад|{0} секунды назад|{0} секунд назад|{0} секунды назад",
+			"minute": "{0} минуту назад|{0} минуты назад|{0} минут назад|{0} минуты назад",
+			"hour":   "{0} час назад|{0} часа назад|{0} часов назад|{0} часа назад",
+			"day":    "{0} день назад|{0} дня назад|{0} дней назад|{0} дня назад",
+			"week":   "{0} неделю назад|{0} недели назад|{0} недель назад|{0} недели назад",
+			"month":  "{0} месяц назад|{0} месяца назад|{0} месяцев назад|{0} месяца назад",
+			"year":   "{0} год назад|{0} года назад|{0} лет назад|{0} года назад",
+		},
+		words: map[string]string{
+			"second0": "сейчас", "day-2": "позавчера", "day-1": "вчера", "day0": "сегодня", "day1": "завтра", "day2": "послезавтра",
+			"week-1": "на прошлой неделе", "week1": "на следующей неделе", "month-1": "в прошлом месяце", "month1": "в следующем месяце",
+			"year-1": "в прошлом году", "year1": "в следующем году",
+		},
+		duration:    map[string]string{"second": "{0} с", "minute": "{0} мин", "hour": "{0} ч", "day": "{0} дн."},
+		durationSep: " ",
+	},
+	"sv": {
+		future: map[string]string{
+			"second": "om {0} sekund|om {0} sekunder", "minute": "om {0} minut|om {0} minuter", "hour": "om {0} timme|om {0} timmar",
+			"day": "om {0} dag|om {0} dagar", "week": "om {0} vecka|om {0} veckor", "month": "om {0} månad|om {0} månader", "year": "om {0} år",
+		},
+		past: map[string]string{
+			"second": "för {0} sekund sedan|för {0} sekunder sedan", "minute": "för {0} minut sedan|för {0} minuter sedan",
+			"hour": "för {0} timme sedan|för {0} timmar sedan", "day": "för {0} dag sedan|för {0} dagar sedan",
+			"week": "för {0} vecka sedan|för {0} veckor sedan", "month": "för {0} månad sedan|för {0} månader sedan",
+			"year": "för {0} år sedan",
+		},
+		words: map[string]string{
+			"second0": "nu", "day-2": "i förrgår", "day-1": "i går", "day0": "i dag", "day1": "i morgon", "day2": "i övermorgon",
+			"week-1": "förra veckan", "week1": "nästa vecka", "month-1": "förra månaden", "month1": "nästa månad",
+			"year-1": "i fjol", "year1": "nästa år",
+		},
+		duration:    map[string]string{"second": "{0} s", "minute": "{0} min", "hour": "{0} tim", "day": "{0} d"},
+		durationSep: " ",
+	},
+	"tr": {
+		future: map[string]string{
+			"second": "{0} saniye sonra", "minute": "{0} dakika sonra", "hour": "{0} saat sonra", "day": "{0} gün sonra",
+			"week": "{0} hafta sonra", "month": "{0} ay sonra", "year": "{0} yıl sonra",
+		},
+		past: map[string]string{
+			"second": "{0} saniye önce", "minute": "{0} dakika önce", "hour": "{0} saat önce", "day": "{0} gün önce",
+			"week": "{0} hafta önce", "month": "{0} ay önce", "year": "{0} yıl önce",
+		},
+		words: map[string]string{
+			"second0": "şimdi", "day-2": "evvelsi gün", "day-1": "dün", "day0": "bugün", "day1": "yarın", "day2": "öbür gün",
+			"week-1": "geçen hafta", "week1": "gelecek hafta", "month-1": "geçen ay", "month1": "gelecek ay",
+			"year-1": "geçen yıl", "year1": "gelecek yıl",
+		},
+		duration:    map[string]string{"second": "{0} sn.", "minute": "{0} dk.", "hour": "{0} sa.", "day": "{0} gün"},
+		durationSep: " ",
+	},
+	"uk": {
+		future: map[string]string{
+			"second": "через {0} секунду|через {0} секунди|через {0} секунд|через {0} секунди",
+			"minute": "через {0} хвилину|через {0} хвилини|через {0} хвилин|через {0} хвилини",
+			"hour":   "через {0} годину|через {0} години|через {0} годин|через {0} години",
+			"day":    "через {0} день|через {0} дні|через {0} днів|через {0} дня",
+			"week":   "через {0} тиждень|через {0} тижні|через {0} тижнів|через {0} тижня",
+			"month":  "через {0} місяць|через {0} місяці|через {0} місяців|через {0} місяця",
+			"year":   "через {0} рік|через {0} роки|через {0} років|через {0} року",
+		},
+		past: map[string]string{
+			"second": "{0} секунду тому|{0} секунди тому|{0} секунд тому|{0} секунди тому",
+			"minute": "{0} хвилину тому|{0} хвилини тому|{0} хвилин тому|{0} хвилини тому",
+			"hour":   "{0} годину тому|{0} години тому|{0} годин тому|{0} години тому",
+			"day":    "{0} день тому|{0} дні тому|{0} днів тому|{0} дня тому",
+			"week":   "{0} тиждень тому|{0} тижні тому|{0} тижнів тому|{0} тижня тому",
+			"month":  "{0} місяць тому|{0} місяці тому|{0} місяців тому|{0} місяця тому",
+			"year":   "{0} рік тому|{0} роки тому|{0} років тому|{0} року тому",
+		},
+		words: map[string]string{
+			"second0": "зараз", "day-2": "позавчора", "day-1": "учора", "day0": "сьогодні", "day1": "завтра", "day2": "післязавтра",
+			"week-1": "минулого тижня", "week1": "наступного тижня", "month-1": "минулого місяця", "month1": "наступного місяця",
+			"year-1": "торік", "year1": "наступного року",
+		},
+		duration:    map[string]string{"second": "{0} с", "minute": "{0} хв", "hour": "{0} год", "day": "{0} дн."},
+		durationSep: " ",
+	},
+	"vi": {
+		future: map[string]string{
+			"second": "sau {0} giây nữa", "minute": "sau {0} phút nữa", "hour": "sau {0} giờ nữa", "day": "sau {0} ngày nữa",
+			"week": "sau {0} tuần nữa", "month": "sau {0} tháng nữa", "year": "sau {0} năm nữa",
+		},
+		past: map[string]string{
+			"second": "{0} giây trước", "minute": "{0} phút trước", "hour": "{0} giờ trước", "day": "{0} ngày trước",
+			"week": "{0} tuần trước", "month": "{0} tháng trước", "year": "{0} năm trước",
+		},
+		words: map[string]string{
+			"second0": "bây giờ", "day-2": "Hôm kia", "day-1": "Hôm qua", "day0": "Hôm nay", "day1": "Ngày mai", "day2": "Ngày kia",
+			"week-1": "tuần trước", "week1": "tuần sau", "month-1": "tháng trước", "month1": "tháng sau",
+			"year-1": "năm ngoái", "year1": "năm sau",
+		},
+		duration:    map[string]string{"second": "{0} giây", "minute": "{0} phút", "hour": "{0} giờ", "day": "{0} ngày"},
+		durationSep: " ",
+	},
+	"zh": {
+		future: map[string]string{
+			"second": "{0}秒钟后", "minute": "{0}分钟后", "hour": "{0}小时后", "day": "{0}天后",
+			"week": "{0}周后", "month": "{0}个月后", "year": "{0}年后",
+		},
+		past: map[string]string{
+			"second": "{0}秒钟前", "minute": "{0}分钟前", "hour": "{0}小时前", "day": "{0}天前",
+			"week": "{0}周前", "month": "{0}个月前", "year": "{0}年前",
+		},
+		words: map[string]string{
+			"second0": "现在", "day-2": "前天", "day-1": "昨天", "day0": "今天", "day1": "明天", "day2": "后天",
+			"week-1": "上周", "week1": "下周", "month-1": "上个月", "month1": "下个月", "year-1": "去年", "year1": "明年",
+		},
+		duration:    map[string]string{"second": "{0}秒", "minute": "{0}分钟", "hour": "{0}小时", "day": "{0}天"},
+		durationSep: "",
+	},
+}
+
+// RelativeTime phrases a time d away from now in the current locale, e.g. "3
+// hours ago" for -3*time.Hour in en-US and "3小时前" in zh, with words such as
+// "yesterday" where the locale has them. d is expressed in the largest unit,
+// from seconds to years, in which it's at least about 1, and rounded to it.
+// Languages without CLDR relative time data are phrased in English.
+func RelativeTime(d time.Duration) string {
+	return currentLocalizer().RelativeTime(d)
+}
+
+// RelativeTimeStyle is like RelativeTime, with style selecting whether words
+// such as "yesterday" are used.
+func RelativeTimeStyle(d time.Duration, style RelativeStyle) string {
+	return currentLocalizer().RelativeTimeStyle(d, style)
+}
+
+// FormatDuration formats d in the current locale with the short names of its
+// days, hours, minutes and seconds, e.g. "1 hr 5 min" in en-US. Units which
+// are zero and fractions of seconds are left out.
+func FormatDuration(d time.Duration) string {
+	return currentLocalizer().FormatDuration(d)
+}
+
+// RelativeTime phrases d like the package level RelativeTime, in the locale
+// of l.
+func (l *Localizer) RelativeTime(d time.Duration) string {
+	return relativeTime(l.tag(), d, RelativeAuto)
+}
+
+// RelativeTimeStyle phrases d like the package level RelativeTimeStyle, in
+// the locale of l.
+func (l *Localizer) RelativeTimeStyle(d time.Duration, style RelativeStyle) string {
+	return relativeTime(l.tag(), d, style)
+}
+
+// FormatDuration formats d like the package level FormatDuration, in the
+// locale of l.
+func (l *Localizer) FormatDuration(d time.Duration) string {
+	return formatDuration(l.tag(), d)
+}
+
+func relativeTime(tag language.Tag, d time.Duration, style RelativeStyle) string {
+	data := relativeDataFor(tag)
+	seconds := math.Abs(d.Seconds())
+	unit := relativeUnits[len(relativeUnits)-1]
+	for _, u := range relativeUnits {
+		if seconds < u.limit {
+			unit = u
+			break
+		}
+	}
+	n := int64(math.Round(seconds / unit.size))
+	if style == RelativeAuto {
+		offset := n
+		if d < 0 {
+			offset = -n
+		}
+		if word, found := data.words[fmt.Sprintf("%s%d", unit.name, offset)]; found {
+			return word
+		}
+	}
+	patterns := data.future
+	if d < 0 {
+		patterns = data.past
+	}
+	return pluralizeInt(tag, patterns[unit.name], n)
+}
+
+func formatDuration(tag language.Tag, d time.Duration) string {
+	data := relativeDataFor(tag)
+	if d < 0 {
+		d = -d
+	}
+	seconds := int64(d / time.Second)
+	var parts []string
+	for _, u := range []struct {
+		name string
+		size int64
+	}{{"day", 86400}, {"hour", 3600}, {"minute", 60}, {"second", 1}} {
+		n := seconds / u.size
+		seconds %= u.size
+		if n > 0 || (u.name == "second" && len(parts) == 0) {
+			parts = append(parts, pluralizeInt(tag, data.duration[u.name], n))
+		}
+	}
+	return strings.Join(parts, data.durationSep)
+}
+
+// relativeDataFor returns the relative time data of the language of tag, or
+// English if it has none.
+func relativeDataFor(tag language.Tag) *relativeData {
+	base, _ := tag.Base()
+	if data, found := relativeLanguages[base.String()]; found {
+		return data
+	}
+	return relativeLanguages["en"]
+}
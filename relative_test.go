@@ -0,0 +1,81 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestRelativeTime(t *testing.T) {
+	day := 24 * time.Hour
+	for _, c := range []struct {
+		locale   string
+		d        time.Duration
+		style    RelativeStyle
+		expected string
+	}{
+		{"en-US", -3 * time.Hour, RelativeAuto, "3 hours ago"},
+		{"en-US", time.Hour, RelativeAuto, "in 1 hour"},
+		{"en-US", -30 * time.Second, RelativeAuto, "30 seconds ago"},
+		{"en-US", 0, RelativeAuto, "now"},
+		{"en-US", 0, RelativeNumeric, "in 0 seconds"},
+		{"en-US", -day, RelativeAuto, "yesterday"},
+		{"en-US", -day, RelativeNumeric, "1 day ago"},
+		{"en-US", -50 * time.Minute, RelativeAuto, "1 hour ago"},
+		{"en-US", 2 * 7 * day, RelativeAuto, "in 2 weeks"},
+		{"en-US", -60 * day, RelativeAuto, "2 months ago"},
+		{"en-US", 365 * day, RelativeAuto, "next year"},
+		{"en-US", -3000 * day, RelativeAuto, "8 years ago"},
+		{"zh-CN", -3 * time.Hour, RelativeAuto, "3小时前"},
+		{"zh-CN", -day, RelativeAuto, "昨天"},
+		{"de", -2 * day, RelativeAuto, "vorgestern"},
+		{"de", 5 * time.Minute, RelativeAuto, "in 5 Minuten"},
+		{"fr", -1 * time.Minute, RelativeAuto, "il y a 1 minute"},
+		{"ru", -21 * time.Minute, RelativeAuto, "21 минуту назад"},
+		{"ru", -3 * time.Minute, RelativeAuto, "3 минуты назад"},
+		{"ru", -5 * time.Minute, RelativeAuto, "5 минут назад"},
+		{"ar", -2 * time.Hour, RelativeAuto, "قبل ساعتين"},
+		{"pl", -3 * time.Hour, RelativeAuto, "3 godziny temu"},
+		{"pl", -5 * time.Hour, RelativeAuto, "5 godzin temu"},
+		{"nl", -day, RelativeAuto, "gisteren"},
+		{"sv", 3 * time.Hour, RelativeAuto, "om 3 timmar"},
+		{"he", -2 * time.Hour, RelativeAuto, "לפני שעתיים"},
+		{"he", -20 * time.Minute, RelativeAuto, "לפני 20 דקות"},
+		{"uk", -21 * time.Minute, RelativeAuto, "21 хвилину тому"},
+		{"cs", -day, RelativeNumeric, "před 1 dnem"},
+		{"xx", -3 * time.Hour, RelativeAuto, "3 hours ago"},
+	} {
+		assert.Equal(t, c.expected, relativeTime(language.Make(c.locale), c.d, c.style), "%v in %v", c.d, c.locale)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	for _, c := range []struct {
+		locale   string
+		d        time.Duration
+		expected string
+	}{
+		{"en-US", time.Hour + 5*time.Minute, "1 hr 5 min"},
+		{"en-US", 50*time.Hour + 2*time.Second + time.Millisecond, "2 days 2 hr 2 sec"},
+		{"en-US", -90 * time.Second, "1 min 30 sec"},
+		{"en-US", 0, "0 sec"},
+		{"de", time.Hour + 5*time.Minute, "1 Std. 5 Min."},
+		{"zh-CN", time.Hour + 5*time.Minute, "1小时5分钟"},
+		{"pl", 3*time.Hour + 5*time.Minute, "3 godz. 5 min"},
+	} {
+		assert.Equal(t, c.expected, formatDuration(language.Make(c.locale), c.d), "%v in %v", c.d, c.locale)
+	}
+}
+
+func TestRelativeCoversLocales(t *testing.T) {
+	for _, locale := range coveredLocales(t) {
+		tag := language.Make(locale)
+		for _, s := range []string{relativeTime(tag, -3*time.Hour, RelativeAuto), relativeTime(tag, -24*time.Hour, RelativeAuto), formatDuration(tag, time.Hour)} {
+			for _, english := range []string{"ago", "yesterday", "hr"} {
+				assert.NotContains(t, s, english, "%s should not fall back to English", locale)
+			}
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"html/template"
+	"regexp"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// TagFunc renders the content of a tag of a rich text message, e.g. as a
+// link. The content is already rendered, and escaped when rendering HTML.
+type TagFunc func(content string) string
+
+// Tags supplies the TagFunc of each tag of rich text messages, e.g.
+//
+//   TRich("TERMS", Tags{"link": func(s string) string { return `<a href="/terms">` + s + "</a>" }})
+//
+// for the message "Read the <link>terms</link>".
+type Tags map[string]TagFunc
+
+type tagKind int
+
+const (
+	tagOpen tagKind = iota + 1
+	tagClose
+	tagSelfClosing
+)
+
+// tagRegexp matches the tags of rich text messages, <name>, </name> and
+// <name/>, as well as HTML tags with attributes.
+var tagRegexp = regexp.MustCompile(`^<(/?)([A-Za-z][A-Za-z0-9_-]*)(\s[^<>]*?)?(/?)>`)
+
+// htmlVoidElements are the HTML elements which have no closing tag.
+var htmlVoidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "source": true, "track": true, "wbr": true,
+}
+
+// tagError reports malformed tags in a message.
+type tagError struct {
+	offset int
+	err    string
+}
+
+func (e *tagError) Error() string {
+	return fmt.Sprintf("tag at offset %d: %s", e.offset, e.err)
+}
+
+// parseTag parses a tag matched by tagRegexp. raw reports HTML tags, which
+// have attributes or are br, hr or wbr. They're text, which is balanced like
+// tags but not rendered as tags, so that translations can keep HTML markup.
+// The kind of raw tags is that of their balancing, which is none for void
+// elements.
+func parseTag(s string) (part messagePart, raw bool) {
+	m := tagRegexp.FindStringSubmatch(s)
+	name, closing, attrs, selfClosing := m[2], m[1] != "", m[3] != "", m[4] != ""
+	part = messagePart{text: s, tagName: name, tagKind: tagOpen}
+	raw = attrs || name == "br" || name == "hr" || name == "wbr"
+	switch {
+	case closing && (attrs || selfClosing):
+		// malformed, keep it as text
+		return messagePart{text: s}, true
+	case closing:
+		part.tagKind = tagClose
+	case selfClosing || raw && htmlVoidElements[strings.ToLower(name)]:
+		part.tagKind = tagSelfClosing
+	}
+	return part, raw
+}
+
+// markup renders the text, arguments and tags of rich text messages.
+type markup struct {
+	// escapes text and arguments if not nil, which means rendering HTML
+	escape func(string) string
+	tags   Tags
+	// keeps the tags as written instead of rendering them
+	raw bool
+	// applied to text and arguments before they're escaped
+	lang    language.Tag
+	mapping CaseMapping
+}
+
+func (m *markup) text(s string) string {
+	if m == nil {
+		return s
+	}
+	s = mapCase(m.lang, s, m.mapping)
+	if m.escape != nil {
+		s = m.escape(s)
+	}
+	return s
+}
+
+// tag renders a tag with its TagFunc, or as its content if it has none.
+func (m *markup) tag(name string, content string) string {
+	if f, found := m.tags[name]; found {
+		return f(content)
+	}
+	return content
+}
+
+// formatPrintfMarkup formats the rich text message s with fmt.Sprintf, quoting
+// its braces and the braces and tags of its string arguments, so that the
+// result parses as a message whose only tags are those of s. String arguments
+// are isolated as selected by isolation in right-to-left locales, BidiHTML
+// wrapping them in a bdi tag rendered by withBidiTag.
+func formatPrintfMarkup(tag language.Tag, s string, args []interface{}, isolation BidiIsolation) string {
+	if directionOf(tag) != RightToLeft {
+		isolation = BidiNone
+	}
+	quoted := make([]interface{}, len(args))
+	for i, arg := range args {
+		if str, ok := arg.(string); ok {
+			str = quoteMessageText(str, "{}<")
+			switch isolation {
+			case BidiControls:
+				str = isolate(str, isolation)
+			case BidiHTML:
+				str = "<bdi>" + str + "</bdi>"
+			}
+			arg = str
+		}
+		quoted[i] = arg
+	}
+	return fmt.Sprintf(quoteMessageText(s, "{}"), quoted...)
+}
+
+// quoteMessageText quotes the apostrophes of s, and its characters in chars,
+// so that they're parsed as literal text.
+func quoteMessageText(s string, chars string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\'':
+			b.WriteString("''")
+		case strings.ContainsRune(chars, r):
+			b.WriteString("'" + string(r) + "'")
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// withBidiTag returns m rendering the bdi tags added by formatPrintfMarkup
+// for BidiHTML as HTML, if it renders HTML.
+func (m *markup) withBidiTag(isolation BidiIsolation) *markup {
+	if isolation != BidiHTML || m.escape == nil {
+		return m
+	}
+	c := *m
+	c.tags = make(Tags, len(m.tags)+1)
+	for name, f := range m.tags {
+		c.tags[name] = f
+	}
+	c.tags["bdi"] = func(s string) string {
+		return "<bdi>" + s + "</bdi>"
+	}
+	return &c
+}
+
+// TRich translates the given key like T into HTML, escaping the text of the
+// translation and the arguments, and rendering the tags of rich text
+// messages such as "Read the <link>terms</link>" with their TagFunc in tags.
+// Tags without TagFunc are replaced by their content.
+func TRich(key string, tags Tags, args ...interface{}) template.HTML {
+	return currentLocalizer().TRich(key, tags, args...)
+}
+
+// TPlain translates the given key like T into plain text, replacing the tags
+// of rich text messages by their content.
+func TPlain(key string, args ...interface{}) string {
+	return currentLocalizer().TPlain(key, args...)
+}
+
+// TRich translates the given key like the package level TRich, in the locale
+// of l.
+func (l *Localizer) TRich(key string, tags Tags, args ...interface{}) template.HTML {
+	return template.HTML(l.translate(key, args, &markup{escape: html.EscapeString, tags: tags}))
+}
+
+// TPlain translates the given key like the package level TPlain, in the
+// locale of l.
+func (l *Localizer) TPlain(key string, args ...interface{}) string {
+	return l.translate(key, args, &markup{})
+}
+
+// validateTags checks that the tags of s don't cross, if it's parsed as a
+// message with placeholders or rendered as rich text. The tags of printf
+// messages are only parsed once they're formatted.
+func validateTags(s string) error {
+	if !hasPlaceholders(s) && printfVerbRegexp.MatchString(s) {
+		return nil
+	}
+	if _, err := parseMessage(s); err != nil {
+		var tagErr *tagError
+		if errors.As(err, &tagErr) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package i18n
+
+import (
+	"errors"
+	"html/template"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRichText(t *testing.T) {
+	defer SetBidiIsolation(BidiControls)
+	l := &Localizer{locale: "en-US", messages: map[string]string{
+		"TERMS":  "Read the <link>terms & conditions</link>",
+		"SHARED": "<b>{name}</b> shared <i>{count, plural, one {<b>#</b> file} other {<b>#</b> files}}</i><icon/>",
+		"HTML":   `See <a href="/help">help</a>,<br>{0} <b>now</b>`,
+		"QUOTED": "Use '<b>' for bold {0}",
+		"LEGACY": "Hello %s <b>x</b>",
+		"PRINTF": "Use {%s} for <b>%d%%</b> of {1}",
+	}}
+	tags := Tags{
+		"link": func(s string) string { return `<a href="/terms">` + s + "</a>" },
+		"b":    func(s string) string { return "<strong>" + s + "</strong>" },
+	}
+	assert.Equal(t, template.HTML(`Read the <a href="/terms">terms &amp; conditions</a>`), l.TRich("TERMS", tags))
+	assert.Equal(t, "Read the terms & conditions", l.TPlain("TERMS"))
+	assert.Equal(t, "Read the <link>terms & conditions</link>", l.T("TERMS"), "T should leave tags as written")
+
+	shared := Params{"name": "<Eve>", "count": 2}
+	assert.Equal(t, template.HTML("<strong>&lt;Eve&gt;</strong> shared <strong>2</strong> files"), l.TRich("SHARED", tags, shared))
+	assert.Equal(t, "<Eve> shared 2 files", l.TPlain("SHARED", shared))
+	assert.Equal(t, "<b><Eve></b> shared <i><b>2</b> files</i><icon/>", l.T("SHARED", shared))
+
+	assert.Equal(t, template.HTML(`See &lt;a href=&#34;/help&#34;&gt;help&lt;/a&gt;,&lt;br&gt;&lt;x&gt; <strong>now</strong>`), l.TRich("HTML", tags, "<x>"))
+	assert.Equal(t, `See <a href="/help">help</a>,<br><x> <b>now</b>`, l.T("HTML", "<x>"))
+	assert.Equal(t, "Use <b> for bold !", l.TPlain("QUOTED", "!"))
+	assert.Equal(t, template.HTML("READ THE <a href=\"/terms\">TERMS &amp; CONDITIONS</a>"), l.TRich("TERMS", tags, CaseUpper))
+	assert.Equal(t, template.HTML("[MISSING]"), l.TRich("MISSING", tags))
+	assert.Equal(t, template.HTML("Hello &lt;Eve&gt; <strong>x</strong>"), l.TRich("LEGACY", tags, "<Eve>"), "should format printf messages")
+	assert.Equal(t, "Hello <b>Eve</b> x", l.TPlain("LEGACY", "<b>Eve</b>"), "should keep the tags of arguments as text")
+	assert.Equal(t, template.HTML("Use {it&#39;s} for <strong>50%</strong> of {1}"), l.TRich("PRINTF", tags, "it's", 50), "should keep braces and apostrophes")
+
+	he := &Localizer{locale: "he", messages: map[string]string{"HELLO": "<b>שלום</b> %s"}}
+	assert.Equal(t, template.HTML("<strong>שלום</strong> \u2068&lt;Eve&gt;\u2069"), he.TRich("HELLO", tags, "<Eve>"))
+	SetBidiIsolation(BidiHTML)
+	assert.Equal(t, template.HTML("<strong>שלום</strong> <bdi>&lt;Eve&gt;</bdi>"), he.TRich("HELLO", tags, "<Eve>"))
+	assert.Equal(t, "שלום \u2068<Eve>\u2069", he.TPlain("HELLO", "<Eve>"), "should isolate plain text with controls")
+
+	for _, s := range []string{
+		"<b><i>crossed</b></i>",
+		"<a href=\"x\"><b>crossed</a></b>",
+		"{n, plural, other {<b><i>#</b></i>}}",
+	} {
+		err := validateTags(s)
+		var tagErr *tagError
+		assert.True(t, errors.As(err, &tagErr), "should reject tags of %q", s)
+	}
+	for _, s := range []string{
+		"a < b", "<3", "{broken", "100%", "<b>ok</b><br><img src=\"x.png\"><wbr/>",
+		"<b>unclosed", "not opened</b>", "<a href=\"x\">unclosed", "{n, plural, one {<b>#} other {#</b>}}",
+		"Usage: %s <file>", "a <b> c", "Press <Enter> to continue", "<b>Press <Enter></b>",
+	} {
+		assert.NoError(t, validateTags(s), s)
+	}
+}
+
+func TestLoadMalformedTags(t *testing.T) {
+	defer restoreState()()
+	SetMessagesFunc(func(path string) ([]byte, error) {
+		switch path {
+		case "en.json":
+			return []byte(`{"HELLO": "Hello <b>{0}</b>", "USAGE": "Usage: %s <file>", "PRESS": "Press <Enter> to {0}"}`), nil
+		case "fr.json":
+			return []byte(`{"HELLO": "Bonjour <b><i>{0}</b></i>", "BYE": "Au revoir"}`), nil
+		}
+		return nil, os.ErrNotExist
+	})
+	l, err := NewLocalizer("en_US")
+	if assert.NoError(t, err, "should keep unclosed tags as text") {
+		assert.Equal(t, "Usage: i18n <file>", l.T("USAGE", "i18n"))
+		assert.Equal(t, template.HTML("Usage: i18n &lt;file&gt;"), l.TRich("USAGE", nil, "i18n"))
+		assert.Equal(t, "Press <Enter> to continue", l.T("PRESS", "continue"))
+		assert.Equal(t, template.HTML("Press &lt;Enter&gt; to continue"), l.TRich("PRESS", nil, "continue"))
+	}
+	_, err = NewLocalizer("fr")
+	var loadErr *LoadError
+	if assert.True(t, errors.As(err, &loadErr)) && assert.Len(t, loadErr.Files, 1) {
+		assert.Equal(t, "fr.json", loadErr.Files[0].File)
+		assert.EqualError(t, loadErr.Files[0].Err, "HELLO: tag at offset 17: </b> crosses <i>")
+	}
+}
@@ -0,0 +1,161 @@
+package i18n
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// LocaleStats describes how completely a locale is translated, measured
+// against the catalog of the default locale (en-US merged over en).
+type LocaleStats struct {
+	Locale string
+	// Fallbacks lists the locales searched for translations, in order
+	Fallbacks []string
+	// Total is the number of keys in the default locale catalog
+	Total int
+	// Translated counts the keys supplied by a locale of the same language
+	// as Locale, e.g. by zh-CN or zh for zh-CN
+	Translated int
+	// Fallback counts the keys not supplied by Locale itself but by a later
+	// locale of Fallbacks
+	Fallback int
+	// ByLevel counts the keys supplied by each locale of Fallbacks
+	ByLevel map[string]int
+	// Empty counts the keys translated to an empty string
+	Empty int
+	// Identical counts the translated keys whose translation is the same as
+	// in the default locale catalog
+	Identical int
+	// Extra counts the keys defined for the locale which aren't in the
+	// default locale catalog
+	Extra int
+}
+
+// Stats computes the translation coverage of the given locale, leaving out
+// glossary terms. Files which exist but fail to load are reported as a
+// *LoadError, along with the stats computed from the other files.
+func Stats(locale string) (*LocaleStats, error) {
+	if matched, _ := regexp.MatchString(localeRegexp, locale); !matched {
+		return nil, fmt.Errorf("Malformated locale string %s", locale)
+	}
+	locale = strings.Replace(locale, "_", "-", -1)
+	lang := strings.Split(locale, "-")[0]
+	fallbacks := fallbackChain(locale)
+	var loadErr *LoadError
+	levels := make([]map[string]string, len(fallbacks))
+	for i, l := range fallbacks {
+		c, _, _, errs := loadLocale(l, false)
+		if len(errs) > 0 {
+			if loadErr == nil {
+				loadErr = &LoadError{Locale: locale}
+			}
+			loadErr.Files = append(loadErr.Files, errs...)
+		}
+		if c != nil {
+			// glossary terms aren't messages to translate
+			for k := range c.Messages {
+				if _, ok := SplitGlossaryKey(k); ok {
+					delete(c.Messages, k)
+				}
+			}
+			levels[i] = c.Messages
+		}
+	}
+
+	source := make(map[string]string)
+	for i := len(fallbacks) - 1; i >= 0; i-- {
+		if fallbacks[i] == defaultLang || fallbacks[i] == defaultLocale {
+			for k, v := range levels[i] {
+				source[k] = v
+			}
+		}
+	}
+
+	stats := &LocaleStats{
+		Locale:    locale,
+		Fallbacks: fallbacks,
+		Total:     len(source),
+		ByLevel:   make(map[string]int, len(fallbacks)),
+	}
+	for key, sourceValue := range source {
+		for i, m := range levels {
+			value, found := m[key]
+			if !found {
+				continue
+			}
+			level := fallbacks[i]
+			stats.ByLevel[level]++
+			if i > 0 {
+				stats.Fallback++
+			}
+			if strings.Split(level, "-")[0] == lang {
+				stats.Translated++
+				if value == sourceValue {
+					stats.Identical++
+				}
+			}
+			if value == "" {
+				stats.Empty++
+			}
+			break
+		}
+	}
+	seen := make(map[string]bool)
+	for i, m := range levels {
+		if fallbacks[i] == defaultLang || fallbacks[i] == defaultLocale {
+			continue
+		}
+		for key := range m {
+			if _, found := source[key]; !found && !seen[key] {
+				seen[key] = true
+				stats.Extra++
+			}
+		}
+	}
+
+	if loadErr != nil {
+		return stats, loadErr
+	}
+	return stats, nil
+}
+
+// WriteMetrics writes the given stats to w in the Prometheus text exposition
+// format, one gauge per field labelled with the locale.
+func WriteMetrics(w io.Writer, stats ...*LocaleStats) error {
+	gauges := []struct {
+		name  string
+		help  string
+		value func(s *LocaleStats) int
+	}{
+		{"i18n_keys", "Number of keys in the default locale catalog.", func(s *LocaleStats) int { return s.Total }},
+		{"i18n_translated_keys", "Number of keys translated in the language of the locale.", func(s *LocaleStats) int { return s.Translated }},
+		{"i18n_fallback_keys", "Number of keys served by a fallback locale.", func(s *LocaleStats) int { return s.Fallback }},
+		{"i18n_empty_keys", "Number of keys translated to an empty string.", func(s *LocaleStats) int { return s.Empty }},
+		{"i18n_identical_keys", "Number of translated keys identical to the default locale.", func(s *LocaleStats) int { return s.Identical }},
+		{"i18n_extra_keys", "Number of keys not in the default locale catalog.", func(s *LocaleStats) int { return s.Extra }},
+	}
+	for _, g := range gauges {
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n", g.name, g.help, g.name); err != nil {
+			return err
+		}
+		for _, s := range stats {
+			if _, err := fmt.Fprintf(w, "%s{locale=%q} %d\n", g.name, s.Locale, g.value(s)); err != nil {
+				return err
+			}
+		}
+	}
+	const level = "i18n_level_keys"
+	if _, err := fmt.Fprintf(w, "# HELP %s Number of keys served by each locale of the fallback chain.\n# TYPE %s gauge\n", level, level); err != nil {
+		return err
+	}
+	for _, s := range stats {
+		for _, l := range s.Fallbacks {
+			if _, err := fmt.Fprintf(w, "%s{locale=%q,level=%q} %d\n", level, s.Locale, l, s.ByLevel[l]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
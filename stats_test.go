@@ -0,0 +1,61 @@
+package i18n
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStats(t *testing.T) {
+	defer restoreState()()
+	SetMessagesDir("locale")
+
+	s, err := Stats("zh_CN")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "zh-CN", s.Locale)
+		assert.Equal(t, []string{"zh-CN", "zh", "en-US", "en"}, s.Fallbacks)
+		assert.Equal(t, 4, s.Total)
+		assert.Equal(t, 1, s.Translated)
+		assert.Equal(t, 4, s.Fallback)
+		assert.Equal(t, map[string]int{"zh": 1, "en-US": 1, "en": 2}, s.ByLevel)
+		assert.Equal(t, 1, s.Empty, "BLANK should count as empty")
+		assert.Equal(t, 0, s.Identical)
+		assert.Equal(t, 2, s.Extra)
+	}
+
+	s, err = Stats("en-US")
+	if assert.NoError(t, err) {
+		assert.Equal(t, 4, s.Translated)
+		assert.Equal(t, 3, s.Fallback)
+		assert.Equal(t, 4, s.Identical)
+		assert.Equal(t, 0, s.Extra)
+	}
+
+	var buf bytes.Buffer
+	if assert.NoError(t, WriteMetrics(&buf, s)) {
+		assert.Contains(t, buf.String(), "# TYPE i18n_translated_keys gauge\ni18n_translated_keys{locale=\"en-US\"} 4\n")
+		assert.Contains(t, buf.String(), "i18n_level_keys{locale=\"en-US\",level=\"en\"} 3\n")
+	}
+
+	_, err = Stats("e0")
+	assert.Error(t, err, "should error on malformed locale")
+
+	files := map[string]string{
+		"en.json": `{"{{APP_NAME}}": "Lantern", "WELCOME": "Welcome to {{APP_NAME}}"}`,
+		"fr.json": `{"{{APP_NOM}}": "Lanterne", "WELCOME": "Bienvenue dans {{APP_NOM}}"}`,
+	}
+	SetMessagesFunc(func(path string) ([]byte, error) {
+		if s, found := files[path]; found {
+			return []byte(s), nil
+		}
+		return nil, os.ErrNotExist
+	})
+	s, err = Stats("fr")
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, s.Total, "shouldn't count glossary terms")
+		assert.Equal(t, 1, s.Translated)
+		assert.Equal(t, 0, s.Extra)
+	}
+}
@@ -0,0 +1,108 @@
+package i18n
+
+import (
+	"html/template"
+	"time"
+)
+
+// FuncMap returns functions bound to the locale of l for text/template and
+// html/template, which can be passed to the Funcs method of templates:
+//
+//   T          translates a key, like l.T, e.g. {{T "HELLO" .Name}}
+//   TN         translates a key for a quantity, like l.TN
+//   TC         translates a key in a context, like l.TC
+//   THTML      translates a key into template.HTML, for trusted translations
+//              containing markup; string arguments are escaped
+//   number     formats a number, like l.FormatNumber
+//   percent    formats a percentage, like l.FormatPercent
+//   compact    formats a number in compact form, like l.FormatCompact
+//   ordinal    formats an ordinal number, like l.FormatOrdinal
+//   currency   formats an amount of a currency, e.g. {{currency .Price "EUR"}}
+//   date       formats a time.Time as a date, with an optional style
+//   time       formats a time.Time as a time, with an optional style
+//   datetime   formats a time.Time as a date and time, with an optional style
+//   list       joins a []string as a conjunction, like l.FormatList
+//
+// In html/template, the strings returned by T, TN and TC are escaped like any
+// other value, so their arguments are isolated with control characters in
+// right-to-left locales even with BidiHTML.
+func FuncMap(l *Localizer) map[string]interface{} {
+	return map[string]interface{}{
+		"T": func(key string, args ...interface{}) string {
+			return l.translate(key, args, &markup{raw: true})
+		},
+		"TN": func(key string, n interface{}, args ...interface{}) string {
+			return l.translate(key, append(append([]interface{}(nil), args...), Params{"count": n}), &markup{raw: true})
+		},
+		"TC": func(context string, key string, args ...interface{}) string {
+			return l.translate(ContextKey(context, key), args, &markup{raw: true})
+		},
+		"THTML": func(key string, args ...interface{}) template.HTML {
+			// the translation and the escaped arguments are HTML already
+			return template.HTML(l.translate(key, escapeArgs(args), &markup{escape: func(s string) string { return s }, raw: true}))
+		},
+		"number":   l.FormatNumber,
+		"percent":  l.FormatPercent,
+		"compact":  l.FormatCompact,
+		"ordinal":  l.FormatOrdinal,
+		"currency": l.FormatCurrency,
+		"date": func(t time.Time, style ...string) string {
+			return l.FormatDate(t, optionalStyle(style))
+		},
+		"time": func(t time.Time, style ...string) string {
+			return l.FormatTime(t, optionalStyle(style))
+		},
+		"datetime": func(t time.Time, style ...string) string {
+			return l.FormatDateTime(t, optionalStyle(style))
+		},
+		"list": func(items []string) string {
+			return l.FormatList(items, ListAnd)
+		},
+	}
+}
+
+func optionalStyle(style []string) string {
+	if len(style) == 0 {
+		return ""
+	}
+	return style[0]
+}
+
+// escapeArgs HTML escapes the strings among args, including the values of
+// Params and the items of lists. template.HTML values are trusted as is.
+func escapeArgs(args []interface{}) []interface{} {
+	escaped := make([]interface{}, len(args))
+	for i, arg := range args {
+		escaped[i] = escapeArg(arg)
+	}
+	return escaped
+}
+
+func escapeArg(arg interface{}) interface{} {
+	switch v := arg.(type) {
+	case string:
+		return template.HTMLEscapeString(v)
+	case template.HTML:
+		return string(v)
+	case []string:
+		return escapeStrings(v)
+	case List:
+		return List{Items: escapeStrings(v.Items), Style: v.Style}
+	case Params:
+		params := make(Params, len(v))
+		for name, value := range v {
+			params[name] = escapeArg(value)
+		}
+		return params
+	default:
+		return arg
+	}
+}
+
+func escapeStrings(s []string) []string {
+	escaped := make([]string, len(s))
+	for i, item := range s {
+		escaped[i] = template.HTMLEscapeString(item)
+	}
+	return escaped
+}
@@ -0,0 +1,68 @@
+package i18n
+
+import (
+	htmltemplate "html/template"
+	"strings"
+	"testing"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuncMap(t *testing.T) {
+	l := &Localizer{locale: "de", messages: map[string]string{
+		"HELLO": "Hallo {0}!",
+		"FILES": "{count, plural, one {# Datei} other {# Dateien}} in {0}",
+		"TERMS": `Lies die <a href="/agb">AGB</a>, {0}`,
+	}}
+	when := time.Date(2024, 3, 5, 14, 30, 0, 0, time.UTC)
+	data := map[string]interface{}{"Name": "<b>Eve</b>", "When": when, "Items": []string{"A", "B", "C"}}
+
+	text := texttemplate.Must(texttemplate.New("").Funcs(FuncMap(l)).Parse(
+		`{{T "HELLO" .Name}} {{TN "FILES" 3 "Docs"}} {{number 1234.5}} {{currency 9.5 "EUR"}} {{date .When "short"}} {{list .Items}}`))
+	var b strings.Builder
+	if assert.NoError(t, text.Execute(&b, data)) {
+		assert.Equal(t, "Hallo <b>Eve</b>! 3 Dateien in Docs 1.234,5 9,50\u00a0€ 05.03.24 A, B und C", b.String())
+	}
+
+	html := htmltemplate.Must(htmltemplate.New("").Funcs(FuncMap(l)).Parse(
+		`<p>{{T "HELLO" .Name}}</p><p>{{THTML "TERMS" .Name}}</p><p>{{TN "FILES" 1 "Docs"}}</p><p>{{date .When}}</p>`))
+	b.Reset()
+	if assert.NoError(t, html.Execute(&b, data)) {
+		assert.Equal(t, `<p>Hallo &lt;b&gt;Eve&lt;/b&gt;!</p><p>Lies die <a href="/agb">AGB</a>, &lt;b&gt;Eve&lt;/b&gt;</p><p>1 Datei in Docs</p><p>05.03.2024</p>`, b.String())
+	}
+}
+
+func TestFuncMapBidi(t *testing.T) {
+	defer SetBidiIsolation(BidiControls)
+	SetBidiIsolation(BidiHTML)
+	l := &Localizer{locale: "he", messages: map[string]string{
+		"HELLO":  "שלום {0}",
+		"LEGACY": "<b>שלום</b> %s",
+	}}
+	html := htmltemplate.Must(htmltemplate.New("").Funcs(FuncMap(l)).Parse(
+		`<p>{{T "HELLO" .Name}}</p><p>{{THTML "HELLO" .Name}}</p><p>{{THTML "LEGACY" .Name}}</p>`))
+	var b strings.Builder
+	if assert.NoError(t, html.Execute(&b, map[string]interface{}{"Name": "<Eva>"})) {
+		assert.Equal(t, "<p>שלום \u2068&lt;Eva&gt;\u2069</p><p>שלום <bdi>&lt;Eva&gt;</bdi></p><p><b>שלום</b> <bdi>&lt;Eva&gt;</bdi></p>", b.String())
+	}
+}
+
+func TestEscapeArgs(t *testing.T) {
+	assert.Equal(t, []interface{}{
+		"a &amp; b",
+		"<i>trusted</i>",
+		3,
+		[]string{"&lt;x&gt;"},
+		List{Items: []string{"&#34;y&#34;"}, Style: ListOr},
+		Params{"name": "&lt;Eve&gt;", "count": 2},
+	}, escapeArgs([]interface{}{
+		"a & b",
+		htmltemplate.HTML("<i>trusted</i>"),
+		3,
+		[]string{"<x>"},
+		List{Items: []string{`"y"`}, Style: ListOr},
+		Params{"name": "<Eve>", "count": 2},
+	}))
+}
@@ -1,12 +1,12 @@
 package i18n
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"io/ioutil"
 	"os"
 	"path"
-	"regexp"
 	"strings"
 	"sync"
 
@@ -15,9 +15,34 @@ import (
 )
 
 // ReadFunc is the func provided to SetMessagesFunc which returns the byte
-// sequence given a file name
+// sequence given a file name. If the file doesn't exist, it should return
+// either an empty sequence or an error. Unless LoadOptions.StrictRead is set,
+// any error means the file doesn't exist; otherwise only errors wrapping
+// os.ErrNotExist do, and others are reported as failures to load the locale.
 type ReadFunc func(fileName string) ([]byte, error)
 
+// ListFunc returns the names of the files of a message source, such as
+// "en-US.json".
+type ListFunc func() ([]string, error)
+
+// LoadOptions controls how LoadLocale treats translation files which exist
+// but can't be read or decoded.
+type LoadOptions struct {
+	// AllowPartial switches to the locale even if some of its files failed to
+	// load, as long as the others supplied translations. The *LoadError
+	// describing the failures is still returned.
+	AllowPartial bool
+	// StrictRead reports the errors of a ReadFunc passed to SetMessagesFunc
+	// or FuncLayer which don't wrap os.ErrNotExist as failures to load the
+	// locale. By default such a ReadFunc failing means the file doesn't
+	// exist. The errors of other message sources are always reported.
+	StrictRead bool
+	// StrictLength reports the messages longer than the MaxLength of their
+	// key as failures to load the locale. By default they're only listed in
+	// the *LoadError returned along with the loaded locale.
+	StrictLength bool
+}
+
 const (
 	localeRegexp  = "^[a-z]{2}([_-][A-Z]{2}){0,1}$"
 	defaultLocale = "en-US"
@@ -25,13 +50,20 @@ const (
 )
 
 var (
-	log      = golog.LoggerFor("i18n")
-	readFunc = makeReadFunc("locale")
-	trMutex  sync.RWMutex
-	// read from a nil map is ok, so leave it uninitialized here
-	trMap map[string]string
+	log     = golog.LoggerFor("i18n")
+	trMutex sync.RWMutex
+	// the Localizer of the current locale, nil until a locale is set
+	current        *Localizer
+	missingHandler MissingHandler = MissingBracket
 )
 
+// MissingHandler is called by T when the key isn't defined in any locale of
+// the fallback chain. locale is the current locale and fallbacks lists the
+// locales which were searched, in order; it must not be modified. The key of
+// a message looked up in a context is ContextKey(context, key). The returned
+// string is used as the translation.
+type MissingHandler func(key string, locale string, fallbacks []string) string
+
 // T translates the given key into a message based on the current locale,
 // formatting the string using the supplied (optional) args. This method will
 // fall back to other locales if the key isn't defined for the current locale.
@@ -42,26 +74,113 @@ var (
 //   3. default locale        (en_US)
 //   4. lang only of default  (en)
 //
+// If the key isn't found, the result of the MissingHandler set through
+// SetMissingHandler is returned, "[key]" by default.
+//
+// Messages containing placeholders such as {0} or {count, number} are
+// formatted for the current locale (see Params), others with fmt.Sprintf. A
+// CaseMapping among args, such as CaseUpper, is applied to the translation.
 func T(key string, args ...interface{}) string {
+	return currentLocalizer().T(key, args...)
+}
+
+// TN translates the given key like T for the quantity n, which messages refer
+// to as the argument count, e.g. in the message
+//
+//   {count, plural, one {# file} other {# files}} in {0}
+//
+// translated by TN("FILES", 3, "Docs").
+func TN(key string, n interface{}, args ...interface{}) string {
+	return currentLocalizer().TN(key, n, args...)
+}
+
+// TC translates the given key like T, in the given context, e.g. "menu" for
+// "OPEN" as a verb rather than as an adjective. Messages in a context are
+// stored separately from the key without context, as "menu\u0004OPEN" in
+// translation files (see ContextKey), and fall back to other locales in the
+// same context only.
+func TC(context string, key string, args ...interface{}) string {
+	return currentLocalizer().TC(context, key, args...)
+}
+
+// Info returns the MessageInfo of key, as supplied by the translation file of
+// highest precedence in the fallback chain of the current locale which
+// describes it. Keys in a context are given by ContextKey.
+func Info(key string) (MessageInfo, bool) {
+	return currentLocalizer().Info(key)
+}
+
+// Origin returns the origin of the translation of key in the current locale,
+// i.e. the file supplying it, prefixed with the name of its Layer if it has
+// one, e.g. "customer:zh-CN.json". Keys in a context are given by ContextKey.
+func Origin(key string) (string, bool) {
+	return currentLocalizer().Origin(key)
+}
+
+// currentLocalizer returns the Localizer of the current locale, or one without
+// translations if no locale is set.
+func currentLocalizer() *Localizer {
 	trMutex.RLock()
 	defer trMutex.RUnlock()
-	s, found := trMap[key]
-	if !found {
-		return fmt.Sprintf("[%v]", key)
+	if current == nil {
+		return &Localizer{}
 	}
+	return current
+}
 
-	// Format string
-	if s != "" && len(args) > 0 {
-		s = fmt.Sprintf(s, args...)
+// SetMissingHandler sets the MissingHandler used by T for keys which aren't
+// translated. Passing nil restores the default, MissingBracket.
+func SetMissingHandler(h MissingHandler) {
+	if h == nil {
+		h = MissingBracket
 	}
+	trMutex.Lock()
+	defer trMutex.Unlock()
+	missingHandler = h
+}
+
+// MissingBracket is the default MissingHandler, rendering the key as "[key]",
+// without its context.
+func MissingBracket(key string, locale string, fallbacks []string) string {
+	_, key = SplitContextKey(key)
+	return fmt.Sprintf("[%v]", key)
+}
 
-	return s
+// MissingKey is a MissingHandler rendering the key itself, without its
+// context.
+func MissingKey(key string, locale string, fallbacks []string) string {
+	_, key = SplitContextKey(key)
+	return key
+}
+
+// MissingEmpty is a MissingHandler rendering an empty string.
+func MissingEmpty(key string, locale string, fallbacks []string) string {
+	return ""
+}
+
+// MissingOnce wraps h so that it's only called the first time a key is found
+// missing in a locale; later lookups return the string h returned then. It's
+// useful to report each missing key once, e.g. to a translation backlog.
+func MissingOnce(h MissingHandler) MissingHandler {
+	var mx sync.Mutex
+	seen := make(map[string]string)
+	return func(key string, locale string, fallbacks []string) string {
+		id := locale + "\x00" + key
+		mx.Lock()
+		defer mx.Unlock()
+		s, found := seen[id]
+		if !found {
+			s = h(key, locale, fallbacks)
+			seen[id] = s
+		}
+		return s
+	}
 }
 
 // SetMessagesDir sets the directory from which to load translations
 // if they are not under the default directory 'locale'
 func SetMessagesDir(d string) {
-	readFunc = makeReadFunc(d)
+	SetMessageLayers(DirLayer("", d))
 }
 
 func makeReadFunc(d string) ReadFunc {
@@ -69,7 +188,7 @@ func makeReadFunc(d string) ReadFunc {
 		fileName := path.Join(d, p)
 		var f *os.File
 		if f, err = os.Open(fileName); err != nil {
-			err = fmt.Errorf("Error open file %s: %s", fileName, err)
+			err = fmt.Errorf("Error open file %s: %w", fileName, err)
 			return
 		}
 		defer func() {
@@ -84,9 +203,48 @@ func makeReadFunc(d string) ReadFunc {
 	}
 }
 
-// SetMessagesFunc tells i18n to read translations through ReadFunc
+func makeListFunc(d string) ListFunc {
+	return func() ([]string, error) {
+		return listFS(os.DirFS(d))
+	}
+}
+
+// SetMessagesFunc tells i18n to read translations through ReadFunc. Such a
+// message source can't be enumerated by AvailableLocales.
 func SetMessagesFunc(f ReadFunc) {
-	readFunc = f
+	SetMessageLayers(FuncLayer("", f))
+}
+
+// SetMessagesFS tells i18n to read translations from the root of fsys, e.g.
+// an embed.FS.
+func SetMessagesFS(fsys fs.FS) {
+	SetMessageLayers(FSLayer("", fsys))
+}
+
+// SetMessagesTar tells i18n to read translations from a tar archive, such as
+// locale.Resources.
+func SetMessagesTar(archive []byte) error {
+	l, err := TarLayer("", archive)
+	if err != nil {
+		return err
+	}
+	SetMessageLayers(l)
+	return nil
+}
+
+// listFS returns the names of the files at the root of fsys.
+func listFS(fsys fs.FS) ([]string, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() {
+			names = append(names, e.Name())
+		}
+	}
+	return names, nil
 }
 
 // UseOSLocale detect OS locale for current user and let i18n to use it
@@ -101,51 +259,101 @@ func UseOSLocale() (string, error) {
 }
 
 // SetLocale sets the current locale to the given value. If the locale is not in
-// a valid format, this function will return an error and leave the current
-// locale as is.
+// a valid format, or if any of its translation files exists but can't be read
+// or decoded, this function will return an error and leave the current locale
+// as is. Failures to load files are returned as a *LoadError. Translations
+// longer than the MaxLength of their key are listed in a *LoadError returned
+// along with the locale, which is set anyway unless LoadOptions.StrictLength.
 func SetLocale(locale string) (string, error) {
-	if matched, _ := regexp.MatchString(localeRegexp, locale); !matched {
-		return "", fmt.Errorf("Malformated locale string %s", locale)
-	}
-	const sep = "-"
-	locale = strings.Replace(locale, "_", sep, -1)
-	parts := strings.Split(locale, sep)
-	lang := parts[0]
-	log.Debugf("Setting locale %v", locale)
-	newTrMap := make(map[string]string)
-	mergeLocaleToMap(newTrMap, defaultLang)
-	mergeLocaleToMap(newTrMap, defaultLocale)
-	mergeLocaleToMap(newTrMap, lang)
-	mergeLocaleToMap(newTrMap, locale)
-	if len(newTrMap) == 0 {
-		return "", fmt.Errorf("Not found any translations, locale not set")
-	}
-	log.Tracef("Translations: %v", newTrMap)
+	return LoadLocale(locale, LoadOptions{})
+}
+
+// LoadLocale is like SetLocale, with opts deciding whether a partially loaded
+// locale is acceptable. If it is, the locale is set and the *LoadError listing
+// the files which failed is returned along with it.
+func LoadLocale(locale string, opts LoadOptions) (string, error) {
+	l, err := LoadLocalizer(locale, opts)
+	if l == nil {
+		return "", err
+	}
+	log.Debugf("Setting locale %v", l.locale)
 	trMutex.Lock()
 	defer trMutex.Unlock()
-	trMap = newTrMap
-	return locale, nil
+	current = l
+	return l.locale, err
 }
 
-func mergeLocaleToMap(dst map[string]string, locale string) {
-	if m, e := loadMapFromFile(locale); e != nil {
-		log.Tracef("Locale %s not loaded: %s", locale, e)
-	} else {
-		for k, v := range m {
-			dst[k] = v
+// fallbackChain returns the locales searched for translations when locale is
+// the current locale, in order of precedence and without duplicates.
+func fallbackChain(locale string) []string {
+	lang := strings.Split(locale, "-")[0]
+	var chain []string
+	for _, l := range []string{locale, lang, defaultLocale, defaultLang} {
+		dup := false
+		for _, c := range chain {
+			dup = dup || c == l
+		}
+		if !dup {
+			chain = append(chain, l)
 		}
 	}
+	return chain
 }
 
-func loadMapFromFile(locale string) (m map[string]string, err error) {
+// mergeLocaleToMap merges the translations of locale from every layer into
+// dst, recording the origin of each key in origins and the MessageInfo of keys
+// in info. It returns the origins of the files loaded.
+func mergeLocaleToMap(dst map[string]string, origins map[string]string, info map[string]MessageInfo, locale string, opts LoadOptions) ([]string, []*FileError) {
+	c, o, files, errs := loadLocale(locale, opts.StrictRead)
+	if c == nil {
+		return nil, errs
+	}
+	for k, v := range c.merged() {
+		dst[k] = v
+		origins[k] = o[k]
+	}
+	for k, v := range c.Info {
+		info[k] = v
+	}
+	return files, errs
+}
+
+// loadCatalogFile loads the translations of the given locale from the layer
+// l, returning a nil catalog without error if there's no file for it. strict
+// is LoadOptions.StrictRead.
+func loadCatalogFile(l Layer, locale string, strict bool) (*Catalog, *FileError) {
 	fileName := locale + ".json"
-	var buf []byte
-	if buf, err = readFunc(fileName); err != nil {
-		err = fmt.Errorf("Error read file %s: %s", fileName, err)
-		return
+	buf, err := l.Read(fileName)
+	if errors.Is(err, os.ErrNotExist) || (err == nil && len(buf) == 0) {
+		return nil, nil
 	}
-	if err = json.Unmarshal(buf, &m); err != nil {
-		err = fmt.Errorf("Error decode json file %s: %s", fileName, err)
+	if err != nil && l.lenient && !strict {
+		log.Tracef("Unable to read %s, assuming it doesn't exist: %v", l.origin(fileName), err)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, &FileError{Layer: l.Name, File: fileName, Offset: -1, Err: err}
+	}
+	c, ferr := decodeCatalog(fileName, locale, buf)
+	if ferr != nil {
+		ferr.Layer = l.Name
+	}
+	return c, ferr
+}
+
+// validateMessages checks the markup of the messages of fileName, and their
+// length against the MaxLength in info, in the order of their keys.
+func validateMessages(fileName string, m map[string]string, info map[string]MessageInfo) *FileError {
+	for _, k := range sortedKeys(m) {
+		err := validateTags(m[k])
+		if err == nil {
+			// variants have the MessageInfo of their key
+			infoKey, _ := splitRegisterKey(k)
+			err = checkLength(m[k], info[infoKey])
+		}
+		if err != nil {
+			return &FileError{File: fileName, Offset: -1, Err: fmt.Errorf("%s: %w", describeKey(k), err)}
+		}
 	}
-	return
+	return nil
 }
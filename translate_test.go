@@ -71,6 +71,35 @@ func TestReadFromMemory(t *testing.T) {
 	}
 }
 
+func TestMissingHandler(t *testing.T) {
+	SetMessagesDir("locale")
+	if !assert.NoError(t, setLocale("zh_CN")) {
+		return
+	}
+	defer SetMissingHandler(nil)
+
+	SetMissingHandler(MissingKey)
+	assertTranslation(t, "NOT_EXISTED", "NOT_EXISTED")
+	SetMissingHandler(MissingEmpty)
+	assertTranslation(t, "", "NOT_EXISTED")
+
+	var reported []string
+	SetMissingHandler(MissingOnce(func(key string, locale string, fallbacks []string) string {
+		assert.Equal(t, "zh-CN", locale)
+		assert.Equal(t, []string{"zh-CN", "zh", "en-US", "en"}, fallbacks)
+		reported = append(reported, key)
+		return "?" + key
+	}))
+	assertTranslation(t, "?NOT_EXISTED", "NOT_EXISTED")
+	assertTranslation(t, "?NOT_EXISTED", "NOT_EXISTED")
+	assertTranslation(t, "?ALSO_NOT_EXISTED", "ALSO_NOT_EXISTED")
+	assertTranslation(t, "I speak Chinese!", "ONLY_IN_ZH")
+	assert.Equal(t, []string{"NOT_EXISTED", "ALSO_NOT_EXISTED"}, reported, "should report each missing key once")
+
+	SetMissingHandler(nil)
+	assertTranslation(t, "[NOT_EXISTED]", "NOT_EXISTED")
+}
+
 func TestGoroutine(t *testing.T) {
 	SetMessagesDir("locale")
 	if err := setLocale("en_US"); err != nil {
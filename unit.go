@@ -0,0 +1,600 @@
+package i18n
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// UnitWidth selects how long the names of units are.
+type UnitWidth int
+
+const (
+	// UnitShort uses abbreviations, e.g. "12.5 MB".
+	UnitShort UnitWidth = iota
+	// UnitLong spells units out, e.g. "12.5 megabytes".
+	UnitLong
+	// UnitNarrow uses the shortest abbreviations, e.g. "12.5MB".
+	UnitNarrow
+)
+
+// ByteUnits selects the prefixes of byte units used by FormatBytes.
+type ByteUnits int
+
+const (
+	// BytesSI uses SI prefixes, in powers of 1000, e.g. "1.5 MB".
+	BytesSI ByteUnits = iota
+	// BytesIEC uses IEC binary prefixes, in powers of 1024, e.g. "1.4 MiB".
+	BytesIEC
+)
+
+var byteUnits = BytesSI
+
+var (
+	siByteUnits  = []string{"byte", "kilobyte", "megabyte", "gigabyte", "terabyte"}
+	iecByteUnits = []string{"byte", "kibibyte", "mebibyte", "gibibyte", "tebibyte"}
+)
+
+// unitPatterns are the CLDR unit patterns by language, width and unit, as
+// plural patterns. Widths which a language lacks fall back to short, then the
+// short durations of relativeLanguages. Languages without unit patterns fall
+// back to English short.
+var unitPatterns = map[string]map[UnitWidth]map[string]string{
+	"ar": {
+		UnitShort: {
+			"bit": "{0} بت", "kilobit": "{0} كيلوبت", "megabit": "{0} ميغابت", "gigabit": "{0} غيغابت",
+			"byte": "{0} بايت", "kilobyte": "{0} كيلوبايت", "megabyte": "{0} ميغابايت", "gigabyte": "{0} غيغابايت", "terabyte": "{0} تيرابايت",
+			"kilometer-per-hour": "{0} كم/س", "meter-per-second": "{0} م/ث", "mile-per-hour": "{0} ميل/س",
+			"millisecond": "{0} ملي ث",
+			"millimeter":  "{0} مم", "centimeter": "{0} سم", "meter": "{0} م", "kilometer": "{0} كم",
+			"inch": "{0} بوصة", "foot": "{0} قدم", "mile": "{0} ميل",
+			"per-second": "{0}/ث",
+		},
+	},
+	"cs": {
+		UnitShort: {
+			"bit": "{0} b", "kilobit": "{0} kb", "megabit": "{0} Mb", "gigabit": "{0} Gb",
+			"byte": "{0} B", "kilobyte": "{0} kB", "megabyte": "{0} MB", "gigabyte": "{0} GB", "terabyte": "{0} TB",
+			"kilometer-per-hour": "{0} km/h", "meter-per-second": "{0} m/s", "mile-per-hour": "{0} mi/h",
+			"millisecond": "{0} ms",
+			"millimeter":  "{0} mm", "centimeter": "{0} cm", "meter": "{0} m", "kilometer": "{0} km",
+			"inch": "{0} in", "foot": "{0} ft", "mile": "{0} mi",
+			"per-second": "{0}/s",
+		},
+	},
+	"da": {
+		UnitShort: {
+			"bit": "{0} bit", "kilobit": "{0} kb", "megabit": "{0} Mb", "gigabit": "{0} Gb",
+			"byte": "{0} byte", "kilobyte": "{0} kB", "megabyte": "{0} MB", "gigabyte": "{0} GB", "terabyte": "{0} TB",
+			"kilometer-per-hour": "{0} km/h", "meter-per-second": "{0} m/s", "mile-per-hour": "{0} mph",
+			"millisecond": "{0} ms",
+			"millimeter":  "{0} mm", "centimeter": "{0} cm", "meter": "{0} m", "kilometer": "{0} km",
+			"inch": "{0} tom.", "foot": "{0} fod", "mile": "{0} mi",
+			"per-second": "{0}/s",
+		},
+	},
+	"de": {
+		UnitLong: {
+			"bit": "{0} Bit", "kilobit": "{0} Kilobit", "megabit": "{0} Megabit", "gigabit": "{0} Gigabit",
+			"byte": "{0} Byte", "kilobyte": "{0} Kilobyte", "megabyte": "{0} Megabyte", "gigabyte": "{0} Gigabyte", "terabyte": "{0} Terabyte",
+			"kilometer-per-hour": "{0} Kilometer pro Stunde", "meter-per-second": "{0} Meter pro Sekunde", "mile-per-hour": "{0} Meile pro Stunde|{0} Meilen pro Stunde",
+			"millisecond": "{0} Millisekunde|{0} Millisekunden", "second": "{0} Sekunde|{0} Sekunden", "minute": "{0} Minute|{0} Minuten",
+			"hour": "{0} Stunde|{0} Stunden", "day": "{0} Tag|{0} Tage",
+			"millimeter": "{0} Millimeter", "centimeter": "{0} Zentimeter", "meter": "{0} Meter", "kilometer": "{0} Kilometer",
+			"inch": "{0} Zoll", "foot": "{0} Fuß", "mile": "{0} Meile|{0} Meilen",
+			"per-second": "{0} pro Sekunde",
+		},
+		UnitShort: {
+			"inch": "{0} Zoll", "foot": "{0} Fuß",
+		},
+	},
+	"en": {
+		UnitLong: {
+			"bit": "{0} bit|{0} bits", "kilobit": "{0} kilobit|{0} kilobits", "megabit": "{0} megabit|{0} megabits", "gigabit": "{0} gigabit|{0} gigabits",
+			"byte": "{0} byte|{0} bytes", "kilobyte": "{0} kilobyte|{0} kilobytes", "megabyte": "{0} megabyte|{0} megabytes",
+			"gigabyte": "{0} gigabyte|{0} gigabytes", "terabyte": "{0} terabyte|{0} terabytes",
+			"kibibyte": "{0} kibibyte|{0} kibibytes", "mebibyte": "{0} mebibyte|{0} mebibytes",
+			"gibibyte": "{0} gibibyte|{0} gibibytes", "tebibyte": "{0} tebibyte|{0} tebibytes",
+			"kilometer-per-hour": "{0} kilometer per hour|{0} kilometers per hour", "meter-per-second": "{0} meter per second|{0} meters per second",
+			"mile-per-hour": "{0} mile per hour|{0} miles per hour",
+			"millisecond":   "{0} millisecond|{0} milliseconds", "second": "{0} second|{0} seconds", "minute": "{0} minute|{0} minutes",
+			"hour": "{0} hour|{0} hours", "day": "{0} day|{0} days",
+			"millimeter": "{0} millimeter|{0} millimeters", "centimeter": "{0} centimeter|{0} centimeters", "meter": "{0} meter|{0} meters",
+			"kilometer": "{0} kilometer|{0} kilometers", "inch": "{0} inch|{0} inches", "foot": "{0} foot|{0} feet", "mile": "{0} mile|{0} miles",
+			"per-second": "{0} per second",
+		},
+		UnitShort: {
+			"bit": "{0} bit", "kilobit": "{0} kb", "megabit": "{0} Mb", "gigabit": "{0} Gb",
+			"byte": "{0} byte", "kilobyte": "{0} kB", "megabyte": "{0} MB", "gigabyte": "{0} GB", "terabyte": "{0} TB",
+			"kibibyte": "{0} KiB", "mebibyte": "{0} MiB", "gibibyte": "{0} GiB", "tebibyte": "{0} TiB",
+			"kilometer-per-hour": "{0} km/h", "meter-per-second": "{0} m/s", "mile-per-hour": "{0} mph",
+			"millisecond": "{0} ms",
+			"millimeter":  "{0} mm", "centimeter": "{0} cm", "meter": "{0} m", "kilometer": "{0} km",
+			"inch": "{0} in", "foot": "{0} ft", "mile": "{0} mi",
+			"per-second": "{0}/s",
+		},
+		UnitNarrow: {
+			"bit": "{0}bit", "kilobit": "{0}kb", "megabit": "{0}Mb", "gigabit": "{0}Gb",
+			"byte": "{0}B", "kilobyte": "{0}kB", "megabyte": "{0}MB", "gigabyte": "{0}GB", "terabyte": "{0}TB",
+			"kibibyte": "{0}KiB", "mebibyte": "{0}MiB", "gibibyte": "{0}GiB", "tebibyte": "{0}TiB",
+			"kilometer-per-hour": "{0}km/h", "meter-per-second": "{0}m/s", "mile-per-hour": "{0}mph",
+			"millisecond": "{0}ms", "second": "{0}s", "minute": "{0}m", "hour": "{0}h", "day": "{0}d",
+			"millimeter": "{0}mm", "centimeter": "{0}cm", "meter": "{0}m", "kilometer": "{0}km",
+			"inch": "{0}″", "foot": "{0}′", "mile": "{0}mi",
+			"per-second": "{0}/s",
+		},
+	},
+	"es": {
+		UnitLong: {
+			"bit": "{0} bit|{0} bits", "kilobit": "{0} kilobit|{0} kilobits", "megabit": "{0} megabit|{0} megabits", "gigabit": "{0} gigabit|{0} gigabits",
+			"byte": "{0} byte|{0} bytes", "kilobyte": "{0} kilobyte|{0} kilobytes", "megabyte": "{0} megabyte|{0} megabytes",
+			"gigabyte": "{0} gigabyte|{0} gigabytes", "terabyte": "{0} terabyte|{0} terabytes",
+			"kilometer-per-hour": "{0} kilómetro por hora|{0} kilómetros por hora", "meter-per-second": "{0} metro por segundo|{0} metros por segundo",
+			"mile-per-hour": "{0} milla por hora|{0} millas por hora",
+			"millisecond":   "{0} milisegundo|{0} milisegundos", "second": "{0} segundo|{0} segundos", "minute": "{0} minuto|{0} minutos",
+			"hour": "{0} hora|{0} horas", "day": "{0} día|{0} días",
+			"millimeter": "{0} milímetro|{0} milímetros", "centimeter": "{0} centímetro|{0} centímetros", "meter": "{0} metro|{0} metros",
+			"kilometer": "{0} kilómetro|{0} kilómetros", "inch": "{0} pulgada|{0} pulgadas", "foot": "{0} pie|{0} pies", "mile": "{0} milla|{0} millas",
+			"per-second": "{0} por segundo",
+		},
+	},
+	"fa": {
+		UnitShort: {
+			"bit": "{0} بیت", "kilobit": "{0} کیلوبیت", "megabit": "{0} مگابیت", "gigabit": "{0} گیگابیت",
+			"byte": "{0} بایت", "kilobyte": "{0} کیلوبایت", "megabyte": "{0} مگابایت", "gigabyte": "{0} گیگابایت", "terabyte": "{0} ترابایت",
+			"kilometer-per-hour": "{0} ک‌م‌س", "meter-per-second": "{0} م/ث", "mile-per-hour": "{0} مایل در ساعت",
+			"millisecond": "{0} هزارم ث",
+			"millimeter":  "{0} م‌م", "centimeter": "{0} س‌م", "meter": "{0} م", "kilometer": "{0} ک‌م",
+			"inch": "{0} اینچ", "foot": "{0} فوت", "mile": "{0} مایل",
+			"per-second": "{0}/ثانیه",
+		},
+	},
+	"fi": {
+		UnitShort: {
+			"bit": "{0} b", "kilobit": "{0} kb", "megabit": "{0} Mb", "gigabit": "{0} Gb",
+			"byte": "{0} t", "kilobyte": "{0} kt", "megabyte": "{0} Mt", "gigabyte": "{0} Gt", "terabyte": "{0} Tt",
+			"kilometer-per-hour": "{0} km/h", "meter-per-second": "{0} m/s", "mile-per-hour": "{0} mph",
+			"millisecond": "{0} ms",
+			"millimeter":  "{0} mm", "centimeter": "{0} cm", "meter": "{0} m", "kilometer": "{0} km",
+			"inch": "{0} tuuma", "foot": "{0} jalka", "mile": "{0} mi",
+			"per-second": "{0}/s",
+		},
+	},
+	"fr": {
+		UnitLong: {
+			"bit": "{0} bit|{0} bits", "kilobit": "{0} kilobit|{0} kilobits", "megabit": "{0} mégabit|{0} mégabits", "gigabit": "{0} gigabit|{0} gigabits",
+			"byte": "{0} octet|{0} octets", "kilobyte": "{0} kilooctet|{0} kilooctets", "megabyte": "{0} mégaoctet|{0} mégaoctets",
+			"gigabyte": "{0} gigaoctet|{0} gigaoctets", "terabyte": "{0} téraoctet|{0} téraoctets",
+			"kilometer-per-hour": "{0} kilomètre à l’heure|{0} kilomètres à l’heure", "meter-per-second": "{0} mètre par seconde|{0} mètres par seconde",
+			"mile-per-hour": "{0} mille à l’heure|{0} milles à l’heure",
+			"millisecond":   "{0} milliseconde|{0} millisecondes", "second": "{0} seconde|{0} secondes", "minute": "{0} minute|{0} minutes",
+			"hour": "{0} heure|{0} heures", "day": "{0} jour|{0} jours",
+			"millimeter": "{0} millimètre|{0} millimètres", "centimeter": "{0} centimètre|{0} centimètres", "meter": "{0} mètre|{0} mètres",
+			"kilometer": "{0} kilomètre|{0} kilomètres", "inch": "{0} pouce|{0} pouces", "foot": "{0} pied|{0} pieds", "mile": "{0} mille|{0} milles",
+			"per-second": "{0} par seconde",
+		},
+		UnitShort: {
+			"bit": "{0} bit", "kilobit": "{0} kbit", "megabit": "{0} Mbit", "gigabit": "{0} Gbit",
+			"byte": "{0} o", "kilobyte": "{0} ko", "megabyte": "{0} Mo", "gigabyte": "{0} Go", "terabyte": "{0} To",
+			"kibibyte": "{0} Kio", "mebibyte": "{0} Mio", "gibibyte": "{0} Gio", "tebibyte": "{0} Tio",
+			"mile-per-hour": "{0} mi/h", "inch": "{0} po", "foot": "{0} pi",
+		},
+	},
+	"he": {
+		UnitShort: {
+			"bit": "{0} ביט", "kilobit": "{0} קילוביט", "megabit": "{0} מגה ביט", "gigabit": "{0} ג׳יגה ביט",
+			"byte": "{0} בייט", "kilobyte": "{0} ק״ב", "megabyte": "{0} מ״ב", "gigabyte": "{0} ג״ב", "terabyte": "{0} ט״ב",
+			"kilometer-per-hour": "{0} קמ״ש", "meter-per-second": "{0} מ׳/שנ׳", "mile-per-hour": "{0} מי׳/שע׳",
+			"millisecond": "{0} אלפ׳ שנ׳",
+			"millimeter":  "{0} מ״מ", "centimeter": "{0} ס״מ", "meter": "{0} מ׳", "kilometer": "{0} ק״מ",
+			"inch": "{0} אינץ׳", "foot": "{0} רגל", "mile": "{0} מייל",
+			"per-second": "{0}/שנ׳",
+		},
+	},
+	"hi": {
+		UnitShort: {
+			"bit": "{0} बिट", "kilobit": "{0} कि॰बिट", "megabit": "{0} मे॰बिट", "gigabit": "{0} गी॰बिट",
+			"byte": "{0} बाइट", "kilobyte": "{0} केबी", "megabyte": "{0} एमबी", "gigabyte": "{0} जीबी", "terabyte": "{0} टीबी",
+			"kilometer-per-hour": "{0} कि॰मी॰/घं॰", "meter-per-second": "{0} मी॰/से॰", "mile-per-hour": "{0} मील/घं॰",
+			"millisecond": "{0} मि॰से॰",
+			"millimeter":  "{0} मि॰मी॰", "centimeter": "{0} से॰मी॰", "meter": "{0} मी॰", "kilometer": "{0} कि॰मी॰",
+			"inch": "{0} इंच", "foot": "{0} फ़ुट", "mile": "{0} मील",
+			"per-second": "{0}/से॰",
+		},
+	},
+	"it": {
+		UnitLong: {
+			"bit": "{0} bit", "kilobit": "{0} kilobit", "megabit": "{0} megabit", "gigabit": "{0} gigabit",
+			"byte": "{0} byte", "kilobyte": "{0} kilobyte", "megabyte": "{0} megabyte", "gigabyte": "{0} gigabyte", "terabyte": "{0} terabyte",
+			"kilometer-per-hour": "{0} chilometro orario|{0} chilometri orari", "meter-per-second": "{0} metro al secondo|{0} metri al secondo",
+			"mile-per-hour": "{0} miglio orario|{0} miglia orarie",
+			"millisecond":   "{0} millisecondo|{0} millisecondi", "second": "{0} secondo|{0} secondi", "minute": "{0} minuto|{0} minuti",
+			"hour": "{0} ora|{0} ore", "day": "{0} giorno|{0} giorni",
+			"millimeter": "{0} millimetro|{0} millimetri", "centimeter": "{0} centimetro|{0} centimetri", "meter": "{0} metro|{0} metri",
+			"kilometer": "{0} chilometro|{0} chilometri", "inch": "{0} pollice|{0} pollici", "foot": "{0} piede|{0} piedi", "mile": "{0} miglio|{0} miglia",
+			"per-second": "{0} al secondo",
+		},
+	},
+	"ja": {
+		UnitLong: {
+			"bit": "{0} ビット", "kilobit": "{0} キロビット", "megabit": "{0} メガビット", "gigabit": "{0} ギガビット",
+			"byte": "{0} バイト", "kilobyte": "{0} キロバイト", "megabyte": "{0} メガバイト", "gigabyte": "{0} ギガバイト", "terabyte": "{0} テラバイト",
+			"kilometer-per-hour": "時速 {0} キロメートル", "meter-per-second": "秒速 {0} メートル", "mile-per-hour": "時速 {0} マイル",
+			"millisecond": "{0} ミリ秒", "second": "{0} 秒", "minute": "{0} 分", "hour": "{0} 時間", "day": "{0} 日",
+			"millimeter": "{0} ミリメートル", "centimeter": "{0} センチメートル", "meter": "{0} メートル", "kilometer": "{0} キロメートル",
+			"inch": "{0} インチ", "foot": "{0} フィート", "mile": "{0} マイル",
+			"per-second": "毎秒 {0}",
+		},
+	},
+	"ko": {
+		UnitLong: {
+			"bit": "{0}비트", "kilobit": "{0}킬로비트", "megabit": "{0}메가비트", "gigabit": "{0}기가비트",
+			"byte": "{0}바이트", "kilobyte": "{0}킬로바이트", "megabyte": "{0}메가바이트", "gigabyte": "{0}기가바이트", "terabyte": "{0}테라바이트",
+			"kilometer-per-hour": "시속 {0}킬로미터", "meter-per-second": "초속 {0}미터", "mile-per-hour": "시속 {0}마일",
+			"millisecond": "{0}밀리초", "second": "{0}초", "minute": "{0}분", "hour": "{0}시간", "day": "{0}일",
+			"millimeter": "{0}밀리미터", "centimeter": "{0}센티미터", "meter": "{0}미터", "kilometer": "{0}킬로미터",
+			"inch": "{0}인치", "foot": "{0}피트", "mile": "{0}마일",
+			"per-second": "초당 {0}",
+		},
+	},
+	"nb": {
+		UnitShort: {
+			"bit": "{0} bit", "kilobit": "{0} kb", "megabit": "{0} Mb", "gigabit": "{0} Gb",
+			"byte": "{0} B", "kilobyte": "{0} kB", "megabyte": "{0} MB", "gigabyte": "{0} GB", "terabyte": "{0} TB",
+			"kilometer-per-hour": "{0} km/h", "meter-per-second": "{0} m/s", "mile-per-hour": "{0} mph",
+			"millisecond": "{0} ms",
+			"millimeter":  "{0} mm", "centimeter": "{0} cm", "meter": "{0} m", "kilometer": "{0} km",
+			"inch": "{0} tm", "foot": "{0} fot", "mile": "{0} mi",
+			"per-second": "{0}/s",
+		},
+	},
+	"nl": {
+		UnitShort: {
+			"bit": "{0} bit", "kilobit": "{0} kb", "megabit": "{0} Mb", "gigabit": "{0} Gb",
+			"byte": "{0} byte", "kilobyte": "{0} kB", "megabyte": "{0} MB", "gigabyte": "{0} GB", "terabyte": "{0} TB",
+			"kilometer-per-hour": "{0} km/h", "meter-per-second": "{0} m/s", "mile-per-hour": "{0} mi/u",
+			"millisecond": "{0} ms",
+			"millimeter":  "{0} mm", "centimeter": "{0} cm", "meter": "{0} m", "kilometer": "{0} km",
+			"inch": "{0} inch", "foot": "{0} ft", "mile": "{0} mi",
+			"per-second": "{0}/s",
+		},
+	},
+	"pl": {
+		UnitShort: {
+			"bit": "{0} b", "kilobit": "{0} kb", "megabit": "{0} Mb", "gigabit": "{0} Gb",
+			"byte": "{0} B", "kilobyte": "{0} kB", "megabyte": "{0} MB", "gigabyte": "{0} GB", "terabyte": "{0} TB",
+			"kilometer-per-hour": "{0} km/h", "meter-per-second": "{0} m/s", "mile-per-hour": "{0} mph",
+			"millisecond": "{0} ms",
+			"millimeter":  "{0} mm", "centimeter": "{0} cm", "meter": "{0} m", "kilometer": "{0} km",
+			"inch": "{0} cal", "foot": "{0} stopa|{0} stopy|{0} stóp|{0} stopy", "mile": "{0} mi",
+			"per-second": "{0}/s",
+		},
+	},
+	"pt": {
+		UnitLong: {
+			"bit": "{0} bit|{0} bits", "kilobit": "{0} quilobit|{0} quilobits", "megabit": "{0} megabit|{0} megabits", "gigabit": "{0} gigabit|{0} gigabits",
+			"byte": "{0} byte|{0} bytes", "kilobyte": "{0} kilobyte|{0} kilobytes", "megabyte": "{0} megabyte|{0} megabytes",
+			"gigabyte": "{0} gigabyte|{0} gigabytes", "terabyte": "{0} terabyte|{0} terabytes",
+			"kilometer-per-hour": "{0} quilômetro por hora|{0} quilômetros por hora", "meter-per-second": "{0} metro por segundo|{0} metros por segundo",
+			"mile-per-hour": "{0} milha por hora|{0} milhas por hora",
+			"millisecond":   "{0} milissegundo|{0} milissegundos", "second": "{0} segundo|{0} segundos", "minute": "{0} minuto|{0} minutos",
+			"hour": "{0} hora|{0} horas", "day": "{0} dia|{0} dias",
+			"millimeter": "{0} milímetro|{0} milímetros", "centimeter": "{0} centímetro|{0} centímetros", "meter": "{0} metro|{0} metros",
+			"kilometer": "{0} quilômetro|{0} quilômetros", "inch": "{0} polegada|{0} polegadas", "foot": "{0} pé|{0} pés", "mile": "{0} milha|{0} milhas",
+			"per-second": "{0} por segundo",
+		},
+	},
+	"ru": {
+		UnitLong: {
+			"bit":                "{0} бит|{0} бита|{0} бит|{0} бита",
+			"kilobit":            "{0} килобит|{0} килобита|{0} килобит|{0} килобита",
+			"megabit":            "{0} мегабит|{0} мегабита|{0} мегабит|{0} мегабита",
+			"gigabit":            "{0} гигабит|{0} гигабита|{0} гигабит|{0} гигабита",
+			"byte":               "{0} байт|{0} байта|{0} байт|{0} байта",
+			"kilobyte":           "{0} килобайт|{0} килобайта|{0} килобайт|{0} килобайта",
+			"megabyte":           "{0} мегабайт|{0} мегабайта|{0} мегабайт|{0} мегабайта",
+			"gigabyte":           "{0} гигабайт|{0} гигабайта|{0} гигабайт|{0} гигабайта",
+			"terabyte":           "{0} терабайт|{0} терабайта|{0} терабайт|{0} терабайта",
+			"kilometer-per-hour": "{0} километр в час|{0} километра в час|{0} километров в час|{0} километра в час",
+			"meter-per-second":   "{0} метр в секунду|{0} метра в секунду|{0} метров в секунду|{0} метра в секунду",
+			"mile-per-hour":      "{0} миля в час|{0} мили в час|{0} миль в час|{0} мили в час",
+			"millisecond":        "{0} миллисекунда|{0} миллисекунды|{0} миллисекунд|{0} миллисекунды",
+			"second":             "{0} секунда|{0} секунды|{0} секунд|{0} секунды",
+			"minute":             "{0} минута|{0} минуты|{0} минут|{0} минуты",
+			"hour":               "{0} час|{0} часа|{0} часов|{0} часа",
+			"day":                "{0} день|{0} дня|{0} дней|{0} дня",
+			"millimeter":         "{0} миллиметр|{0} миллиметра|{0} миллиметров|{0} миллиметра",
+			"centimeter":         "{0} сантиметр|{0} сантиметра|{0} сантиметров|{0} сантиметра",
+			"meter":              "{0} метр|{0} метра|{0} метров|{0} метра",
+			"kilometer":          "{0} километр|{0} километра|{0} километров|{0} километра",
+			"inch":               "{0} дюйм|{0} дюйма|{0} дюймов|{0} дюйма",
+			"foot":               "{0} фут|{0} фута|{0} футов|{0} фута",
+			"mile":               "{0} миля|{0} мили|{0} миль|{0} мили",
+			"per-second":         "{0} в секунду",
+		},
+		UnitShort: {
+			"bit": "{0} бит", "kilobit": "{0} кбит", "megabit": "{0} Мбит", "gigabit": "{0} Гбит",
+			"byte": "{0} Б", "kilobyte": "{0} КБ", "megabyte": "{0} МБ", "gigabyte": "{0} ГБ", "terabyte": "{0} ТБ",
+			"kibibyte": "{0} КиБ", "mebibyte": "{0} МиБ", "gibibyte": "{0} ГиБ", "tebibyte": "{0} ТиБ",
+			"kilometer-per-hour": "{0} км/ч", "meter-per-second": "{0} м/с", "mile-per-hour": "{0} миль/ч",
+			"millisecond": "{0} мс",
+			"millimeter":  "{0} мм", "centimeter": "{0} см", "meter": "{0} м", "kilometer": "{0} км",
+			"inch": "{0} дюйм.", "foot": "{0} фт", "mile": "{0} миль",
+			"per-second": "{0}/с",
+		},
+	},
+	"sv": {
+		UnitShort: {
+			"bit": "{0} bit", "kilobit": "{0} kb", "megabit": "{0} Mb", "gigabit": "{0} Gb",
+			"byte": "{0} byte", "kilobyte": "{0} kB", "megabyte": "{0} MB", "gigabyte": "{0} GB", "terabyte": "{0} TB",
+			"kilometer-per-hour": "{0} km/h", "meter-per-second": "{0} m/s", "mile-per-hour": "{0} mph",
+			"millisecond": "{0} ms",
+			"millimeter":  "{0} mm", "centimeter": "{0} cm", "meter": "{0} m", "kilometer": "{0} km",
+			"inch": "{0} tum", "foot": "{0} fot", "mile": "{0} eng. mil",
+			"per-second": "{0}/s",
+		},
+	},
+	"tr": {
+		UnitShort: {
+			"bit": "{0} bit", "kilobit": "{0} kb", "megabit": "{0} Mb", "gigabit": "{0} Gb",
+			"byte": "{0} bayt", "kilobyte": "{0} KB", "megabyte": "{0} MB", "gigabyte": "{0} GB", "terabyte": "{0} TB",
+			"kilometer-per-hour": "{0} km/h", "meter-per-second": "{0} m/s", "mile-per-hour": "{0} mil/sa",
+			"millisecond": "{0} ms",
+			"millimeter":  "{0} mm", "centimeter": "{0} cm", "meter": "{0} m", "kilometer": "{0} km",
+			"inch": "{0} inç", "foot": "{0} ft", "mile": "{0} mil",
+			"per-second": "{0}/sn",
+		},
+	},
+	"uk": {
+		UnitShort: {
+			"bit": "{0} біт", "kilobit": "{0} кбіт", "megabit": "{0} Мбіт", "gigabit": "{0} Гбіт",
+			"byte": "{0} Б", "kilobyte": "{0} КБ", "megabyte": "{0} МБ", "gigabyte": "{0} ГБ", "terabyte": "{0} ТБ",
+			"kilometer-per-hour": "{0} км/год", "meter-per-second": "{0} м/с", "mile-per-hour": "{0} миль/год",
+			"millisecond": "{0} мс",
+			"millimeter":  "{0} мм", "centimeter": "{0} см", "meter": "{0} м", "kilometer": "{0} км",
+			"inch": "{0} дюйм.", "foot": "{0} фут.", "mile": "{0} миля|{0} милі|{0} миль|{0} милі",
+			"per-second": "{0}/с",
+		},
+	},
+	"vi": {
+		UnitShort: {
+			"bit": "{0} bit", "kilobit": "{0} kb", "megabit": "{0} Mb", "gigabit": "{0} Gb",
+			"byte": "{0} byte", "kilobyte": "{0} kB", "megabyte": "{0} MB", "gigabyte": "{0} GB", "terabyte": "{0} TB",
+			"kilometer-per-hour": "{0} km/h", "meter-per-second": "{0} m/s", "mile-per-hour": "{0} dặm/giờ",
+			"millisecond": "{0} ms",
+			"millimeter":  "{0} mm", "centimeter": "{0} cm", "meter": "{0} m", "kilometer": "{0} km",
+			"inch": "{0} inch", "foot": "{0} ft", "mile": "{0} dặm",
+			"per-second": "{0}/giây",
+		},
+	},
+	"zh": {
+		UnitLong: {
+			"bit": "{0}比特", "kilobit": "{0}千比特", "megabit": "{0}兆比特", "gigabit": "{0}吉比特",
+			"byte": "{0}字节", "kilobyte": "{0}千字节", "megabyte": "{0}兆字节", "gigabyte": "{0}吉字节", "terabyte": "{0}太字节",
+			"kilometer-per-hour": "每小时{0}公里", "meter-per-second": "每秒{0}米", "mile-per-hour": "每小时{0}英里",
+			"millisecond": "{0}毫秒", "second": "{0}秒钟", "minute": "{0}分钟", "hour": "{0}小时", "day": "{0}天",
+			"millimeter": "{0}毫米", "centimeter": "{0}厘米", "meter": "{0}米", "kilometer": "{0}公里",
+			"inch": "{0}英寸", "foot": "{0}英尺", "mile": "{0}英里",
+			"per-second": "每秒{0}",
+		},
+		UnitShort: {
+			"kilometer-per-hour": "{0}公里/小时", "meter-per-second": "{0}米/秒", "mile-per-hour": "{0}英里/小时",
+			"millisecond": "{0}毫秒",
+			"millimeter":  "{0}毫米", "centimeter": "{0}厘米", "meter": "{0}米", "kilometer": "{0}公里",
+			"inch": "{0}英寸", "foot": "{0}英尺", "mile": "{0}英里",
+			"per-second": "{0}/秒",
+		},
+	},
+}
+
+// SetByteUnits selects the prefixes FormatBytes and FormatByteRate scale
+// bytes with. It defaults to BytesSI.
+func SetByteUnits(units ByteUnits) {
+	trMutex.Lock()
+	defer trMutex.Unlock()
+	byteUnits = units
+}
+
+// FormatUnit formats value, which can be a Decimal or any integer or floating
+// point number, as a quantity of the CLDR unit in the current locale, e.g.
+// "5 kilometers" for unit "kilometer" and UnitLong in en-US or "5 km" for
+// UnitShort. The unit is picked in the plural form of value.
+//
+// Supported units are bit, kilobit, megabit, gigabit, byte, kilobyte,
+// megabyte, gigabyte, terabyte, kibibyte, mebibyte, gibibyte, tebibyte,
+// kilometer-per-hour, meter-per-second, mile-per-hour, millisecond, second,
+// minute, hour, day, millimeter, centimeter, meter, kilometer, inch, foot and
+// mile, and any of them followed by -per-second, e.g. megabit-per-second.
+func FormatUnit(value interface{}, unit string, width UnitWidth) string {
+	return currentLocalizer().FormatUnit(value, unit, width)
+}
+
+// FormatBytes formats n bytes in the current locale, scaled to the largest
+// unit up to terabytes in which it's at least 1 and rounded to a tenth, e.g.
+// "3 GB" or "12.5 MB", with the prefixes selected by SetByteUnits.
+func FormatBytes(n int64, width UnitWidth) string {
+	return currentLocalizer().FormatBytes(n, width)
+}
+
+// FormatByteRate formats a rate of bytes per second like FormatBytes, e.g.
+// "12.5 MB/s".
+func FormatByteRate(bytesPerSecond float64, width UnitWidth) string {
+	return currentLocalizer().FormatByteRate(bytesPerSecond, width)
+}
+
+// FormatUnit formats value like the package level FormatUnit, in the locale
+// of l.
+func (l *Localizer) FormatUnit(value interface{}, unit string, width UnitWidth) string {
+	return formatUnit(l.tag(), value, unit, width)
+}
+
+// FormatBytes formats n like the package level FormatBytes, in the locale of
+// l.
+func (l *Localizer) FormatBytes(n int64, width UnitWidth) string {
+	return formatBytes(l.tag(), float64(n), width, l.byteUnits(), false)
+}
+
+// FormatByteRate formats bytesPerSecond like the package level
+// FormatByteRate, in the locale of l.
+func (l *Localizer) FormatByteRate(bytesPerSecond float64, width UnitWidth) string {
+	return formatBytes(l.tag(), bytesPerSecond, width, l.byteUnits(), true)
+}
+
+func (l *Localizer) byteUnits() ByteUnits {
+	trMutex.RLock()
+	defer trMutex.RUnlock()
+	return byteUnits
+}
+
+func formatUnit(tag language.Tag, value interface{}, unit string, width UnitWidth) string {
+	d, ok := toDecimal(value)
+	if !ok {
+		return fmt.Sprintf("%v %v", value, unit)
+	}
+	return formatQuantity(tag, d.round(3), unit, width)
+}
+
+// formatQuantity formats d as a quantity of unit, falling back to the unit
+// name for unknown units.
+func formatQuantity(tag language.Tag, d Decimal, unit string, width UnitWidth) string {
+	number := numberFormatFor(tag).format(d, 0)
+	if pattern, found := unitPattern(tag, unit, width); found {
+		return strings.Replace(pluralPattern(tag, pattern, d), "{0}", number, 1)
+	}
+	if perUnit := strings.TrimSuffix(unit, "-per-second"); perUnit != unit {
+		if pattern, found := unitPattern(tag, perUnit, width); found {
+			perSecond, _ := unitPattern(tag, "per-second", width)
+			return strings.Replace(perSecond, "{0}", strings.Replace(pluralPattern(tag, pattern, d), "{0}", number, 1), 1)
+		}
+	}
+	log.Debugf("Unknown unit %v", unit)
+	return number + " " + unit
+}
+
+// unitPattern returns the plural patterns of unit in the language of tag.
+func unitPattern(tag language.Tag, unit string, width UnitWidth) (string, bool) {
+	base, _ := tag.Base()
+	lang := base.String()
+	if pattern, found := unitPatterns[lang][width][unit]; found {
+		return pattern, true
+	}
+	if pattern, found := unitPatterns[lang][UnitShort][unit]; found {
+		return pattern, true
+	}
+	if data, found := relativeLanguages[lang]; found {
+		if pattern, found := data.duration[unit]; found {
+			return pattern, true
+		}
+	}
+	if pattern, found := unitPatterns["en"][UnitShort][unit]; found {
+		return pattern, true
+	}
+	pattern, found := relativeLanguages["en"].duration[unit]
+	return pattern, found
+}
+
+// formatBytes formats a number of bytes, or of bytes per second if rate is
+// true, scaled to the largest unit in which it's at least 1.
+func formatBytes(tag language.Tag, n float64, width UnitWidth, units ByteUnits, rate bool) string {
+	names, base := siByteUnits, 1000.0
+	if units == BytesIEC {
+		names, base = iecByteUnits, 1024
+	}
+	i := 0
+	for i < len(names)-1 && math.Abs(n) >= base {
+		n /= base
+		i++
+	}
+	digits := 1
+	if i == 0 {
+		digits = 0
+	}
+	d, ok := floatDecimal(n, 64)
+	if !ok {
+		return fmt.Sprint(n)
+	}
+	d = d.round(digits)
+	// rounding may carry over to the next unit, e.g. 999.96 kB
+	if rounded, _ := strconv.ParseFloat(d.String(), 64); i < len(names)-1 && math.Abs(rounded) >= base {
+		d, _ = floatDecimal(n/base, 64)
+		d = d.round(1)
+		i++
+	}
+	unit := names[i]
+	if rate {
+		unit += "-per-second"
+	}
+	return formatQuantity(tag, d, unit, width)
+}
+
+// parseUnitStyle parses the style of a unit placeholder, made of a unit and
+// an optional width, e.g. "kilometer long".
+func parseUnitStyle(s string) (unit string, width UnitWidth, err error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return "", width, fmt.Errorf("missing unit")
+	}
+	unit = fields[0]
+	if _, found := unitPattern(language.English, strings.TrimSuffix(unit, "-per-second"), UnitLong); !found {
+		return "", width, fmt.Errorf("unknown unit %q", unit)
+	}
+	if len(fields) > 1 {
+		if width, err = parseUnitWidth(fields[1]); err != nil {
+			return "", width, err
+		}
+	}
+	if len(fields) > 2 {
+		return "", width, fmt.Errorf("invalid unit style %q", s)
+	}
+	return unit, width, nil
+}
+
+// parseBytesStyle parses the style of a bytes placeholder, made of an
+// optional "rate" for bytes per second and an optional width, e.g. "rate
+// narrow".
+func parseBytesStyle(s string) (rate bool, width UnitWidth, err error) {
+	for _, field := range strings.Fields(s) {
+		if field == "rate" {
+			rate = true
+			continue
+		}
+		if width, err = parseUnitWidth(field); err != nil {
+			return false, width, err
+		}
+	}
+	return rate, width, nil
+}
+
+func parseUnitWidth(s string) (UnitWidth, error) {
+	switch s {
+	case "short":
+		return UnitShort, nil
+	case "long":
+		return UnitLong, nil
+	case "narrow":
+		return UnitNarrow, nil
+	default:
+		return UnitShort, fmt.Errorf("unknown unit width %q", s)
+	}
+}
+
+// formatUnitArg formats the argument of a unit or bytes placeholder.
+func formatUnitArg(tag language.Tag, v interface{}, typ string, s string) string {
+	if typ == "unit" {
+		unit, width, _ := parseUnitStyle(s)
+		return formatUnit(tag, v, unit, width)
+	}
+	rate, width, _ := parseBytesStyle(s)
+	d, ok := toDecimal(v)
+	if !ok {
+		return fmt.Sprint(v)
+	}
+	trMutex.RLock()
+	units := byteUnits
+	trMutex.RUnlock()
+	n, _ := strconv.ParseFloat(d.String(), 64)
+	return formatBytes(tag, n, width, units, rate)
+}
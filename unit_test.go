@@ -0,0 +1,123 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestFormatUnit(t *testing.T) {
+	for _, c := range []struct {
+		locale   string
+		value    interface{}
+		unit     string
+		width    UnitWidth
+		expected string
+	}{
+		{"en-US", 1, "kilometer", UnitLong, "1 kilometer"},
+		{"en-US", 5, "kilometer", UnitLong, "5 kilometers"},
+		{"en-US", 1.5, "kilometer", UnitLong, "1.5 kilometers"},
+		{"en-US", 5, "kilometer", UnitShort, "5 km"},
+		{"en-US", 5, "kilometer", UnitNarrow, "5km"},
+		{"en-US", 60, "mile-per-hour", UnitShort, "60 mph"},
+		{"en-US", 3, "hour", UnitShort, "3 hr"},
+		{"en-US", 1, "foot", UnitLong, "1 foot"},
+		{"en-US", 2, "foot", UnitLong, "2 feet"},
+		{"en-US", 2.3456, "meter", UnitShort, "2.346 m"},
+		{"en-US", 100, "megabit-per-second", UnitShort, "100 Mb/s"},
+		{"en-US", 1, "megabit-per-second", UnitLong, "1 megabit per second"},
+		{"de", 1234.5, "meter", UnitLong, "1.234,5 Meter"},
+		{"fr", 2, "hour", UnitLong, "2 heures"},
+		{"ru", 1, "day", UnitLong, "1 день"},
+		{"ru", 3, "day", UnitLong, "3 дня"},
+		{"ru", 5, "day", UnitLong, "5 дней"},
+		{"ru", 21, "day", UnitLong, "21 день"},
+		{"ru", 1.5, "day", UnitLong, "1,5 дня"},
+		{"ru", 90, "kilometer-per-hour", UnitShort, "90 км/ч"},
+		{"zh", 3, "kilometer", UnitLong, "3公里"},
+		{"pl", 1, "hour", UnitShort, "1 godz."},
+		{"pl", 5, "foot", UnitLong, "5 stóp"},
+		{"he", 3, "kilometer", UnitShort, "3 ק״מ"},
+		{"xx", 5, "kilometer", UnitLong, "5 kilometers"},
+		{"en-US", 5, "parsec", UnitLong, "5 parsec"},
+	} {
+		assert.Equal(t, c.expected, formatUnit(language.Make(c.locale), c.value, c.unit, c.width), "%v %v in %v", c.value, c.unit, c.locale)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	for _, c := range []struct {
+		locale   string
+		n        float64
+		units    ByteUnits
+		width    UnitWidth
+		rate     bool
+		expected string
+	}{
+		{"en-US", 0, BytesSI, UnitShort, false, "0 byte"},
+		{"en-US", 1, BytesSI, UnitLong, false, "1 byte"},
+		{"en-US", 999, BytesSI, UnitLong, false, "999 bytes"},
+		{"en-US", 1500, BytesSI, UnitShort, false, "1.5 kB"},
+		{"en-US", 12500000, BytesSI, UnitShort, false, "12.5 MB"},
+		{"en-US", 12500000, BytesSI, UnitNarrow, false, "12.5MB"},
+		{"en-US", 3000000000, BytesSI, UnitLong, false, "3 gigabytes"},
+		{"en-US", 999960, BytesSI, UnitShort, false, "1 MB"},
+		{"en-US", 1536, BytesIEC, UnitShort, false, "1.5 KiB"},
+		{"en-US", 1500000, BytesIEC, UnitLong, false, "1.4 mebibytes"},
+		{"en-US", 12500000, BytesSI, UnitShort, true, "12.5 MB/s"},
+		{"en-US", 12500000, BytesSI, UnitLong, true, "12.5 megabytes per second"},
+		{"en-US", 5e15, BytesSI, UnitShort, false, "5,000 TB"},
+		{"fr", 12500000, BytesSI, UnitShort, false, "12,5 Mo"},
+		{"fr", 1536, BytesIEC, UnitShort, false, "1,5 Kio"},
+		{"ru", 5000000, BytesSI, UnitLong, false, "5 мегабайт"},
+		{"ru", 2000000, BytesSI, UnitShort, true, "2 МБ/с"},
+		{"de", 2000000, BytesSI, UnitLong, true, "2 Megabyte pro Sekunde"},
+		{"ar", 0, BytesSI, UnitShort, false, "٠ بايت"},
+		{"ar", 1500, BytesSI, UnitLong, false, "١٫٥ كيلوبايت"},
+	} {
+		assert.Equal(t, c.expected, formatBytes(language.Make(c.locale), c.n, c.width, c.units, c.rate), "%v in %v", c.n, c.locale)
+	}
+}
+
+func TestUnitsCoverLocales(t *testing.T) {
+	for _, locale := range coveredLocales(t) {
+		base, _ := language.Make(locale).Base()
+		lang := base.String()
+		for unit := range unitPatterns["en"][UnitShort] {
+			if strings.HasSuffix(unit, "bibyte") {
+				// the IEC prefixes are international
+				continue
+			}
+			_, short := unitPatterns[lang][UnitShort][unit]
+			_, long := unitPatterns[lang][UnitLong][unit]
+			assert.True(t, short || long, "%s should have the unit %s", locale, unit)
+		}
+		if assert.Contains(t, relativeLanguages, lang, "%s should have the durations", locale) {
+			for _, unit := range []string{"second", "minute", "hour", "day"} {
+				assert.Contains(t, relativeLanguages[lang].duration, unit, "%s should have the unit %s", locale, unit)
+			}
+		}
+	}
+}
+
+func TestUnitPlaceholders(t *testing.T) {
+	l := &Localizer{locale: "en_US", messages: map[string]string{
+		"DISTANCE": "{d, unit, kilometer long} away",
+		"DOWNLOAD": "{0, bytes} at {1, bytes, rate}",
+	}}
+	assert.Equal(t, "3 kilometers away", l.T("DISTANCE", Params{"d": 3}))
+	assert.Equal(t, "1.5 MB at 250 kB/s", l.T("DOWNLOAD", 1500000, 250000))
+	assert.Equal(t, "1.5 MB", l.FormatBytes(1500000, UnitShort))
+
+	SetByteUnits(BytesIEC)
+	defer SetByteUnits(BytesSI)
+	assert.Equal(t, "1.4 MiB at 244.1 KiB/s", l.T("DOWNLOAD", 1500000, 250000))
+	assert.Equal(t, "1.4 MiB/s", l.FormatByteRate(1500000, UnitShort))
+
+	for _, s := range []string{"{d, unit}", "{d, unit, parsec}", "{d, unit, meter wide}", "{d, bytes, wide}"} {
+		_, err := Placeholders(s)
+		assert.Error(t, err, s)
+	}
+}
@@ -0,0 +1,178 @@
+package i18n
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const xliffNamespace = "urn:oasis:names:tc:xliff:document:1.2"
+
+const (
+	// xliffContextType is the context-type of the context of a message
+	xliffContextType = "x-context"
+	// xliffScreenshotType is the context-type of the Screenshot of
+	// MessageInfo
+	xliffScreenshotType = "x-screenshot"
+)
+
+type xliffDoc struct {
+	XMLName xml.Name  `xml:"xliff"`
+	Xmlns   string    `xml:"xmlns,attr,omitempty"`
+	Version string    `xml:"version,attr"`
+	File    xliffFile `xml:"file"`
+}
+
+type xliffFile struct {
+	Original       string      `xml:"original,attr"`
+	Datatype       string      `xml:"datatype,attr"`
+	SourceLanguage string      `xml:"source-language,attr"`
+	TargetLanguage string      `xml:"target-language,attr,omitempty"`
+	Units          []xliffUnit `xml:"body>trans-unit"`
+}
+
+type xliffUnit struct {
+	ID       string `xml:"id,attr"`
+	Resname  string `xml:"resname,attr,omitempty"`
+	MaxWidth int    `xml:"maxwidth,attr,omitempty"`
+	SizeUnit string `xml:"size-unit,attr,omitempty"`
+	Source   string `xml:"source"`
+	// nil if the unit isn't translated
+	Target        *string             `xml:"target"`
+	ContextGroups []xliffContextGroup `xml:"context-group"`
+	Notes         []string            `xml:"note"`
+}
+
+type xliffContextGroup struct {
+	Purpose  string         `xml:"purpose,attr,omitempty"`
+	Contexts []xliffContext `xml:"context"`
+}
+
+type xliffContext struct {
+	Type  string `xml:"context-type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// WriteXLIFF writes the messages of source along with their translations in
+// target to w as an XLIFF 1.2 file. target may be nil to write a file to be
+// translated. Each key is the resname of a trans-unit, and its context a
+// context of type x-context. The MessageInfo of source is written as a note,
+// the maxwidth of the unit and a context of type x-screenshot.
+func WriteXLIFF(w io.Writer, source *Catalog, target *Catalog) error {
+	doc := xliffDoc{
+		Xmlns:   xliffNamespace,
+		Version: "1.2",
+		File: xliffFile{
+			Original:       "messages",
+			Datatype:       "plaintext",
+			SourceLanguage: source.Locale,
+		},
+	}
+	keys := make(map[string]string, len(source.Messages))
+	for k, v := range source.Messages {
+		keys[k] = v
+	}
+	if target != nil {
+		doc.File.TargetLanguage = target.Locale
+		for k := range target.Messages {
+			if _, found := keys[k]; !found {
+				keys[k] = ""
+			}
+		}
+	}
+	for _, key := range sortedKeys(keys) {
+		context, msgKey := SplitContextKey(key)
+		unit := xliffUnit{ID: msgKey, Resname: msgKey, Source: source.Messages[key]}
+		var contexts []xliffContext
+		if context != "" {
+			unit.ID = context + "/" + msgKey
+			contexts = append(contexts, xliffContext{Type: xliffContextType, Value: context})
+		}
+		info := source.Info[key]
+		if info.Screenshot != "" {
+			contexts = append(contexts, xliffContext{Type: xliffScreenshotType, Value: info.Screenshot})
+		}
+		if len(contexts) > 0 {
+			unit.ContextGroups = []xliffContextGroup{{Purpose: "information", Contexts: contexts}}
+		}
+		if info.MaxLength > 0 {
+			unit.MaxWidth, unit.SizeUnit = info.MaxLength, "char"
+		}
+		if info.Description != "" {
+			unit.Notes = []string{info.Description}
+		}
+		if target != nil {
+			if s, found := target.Messages[key]; found {
+				unit.Target = &s
+			}
+		}
+		doc.File.Units = append(doc.File.Units, unit)
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// ReadXLIFF reads the source messages and their translations from an XLIFF
+// 1.2 file such as written by WriteXLIFF. Units without a target are left out
+// of target. The MessageInfo of units is returned with source.
+func ReadXLIFF(r io.Reader) (source *Catalog, target *Catalog, err error) {
+	var doc xliffDoc
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, nil, fmt.Errorf("Error decode XLIFF: %w", err)
+	}
+	if doc.Version != "1.2" {
+		return nil, nil, fmt.Errorf("Unsupported XLIFF version %q", doc.Version)
+	}
+	source = &Catalog{Locale: xliffLocale(doc.File.SourceLanguage), Messages: make(map[string]string)}
+	target = &Catalog{Locale: xliffLocale(doc.File.TargetLanguage), Messages: make(map[string]string)}
+	for _, unit := range doc.File.Units {
+		msgKey := unit.Resname
+		if msgKey == "" {
+			msgKey = unit.ID
+		}
+		var context string
+		var info MessageInfo
+		for _, g := range unit.ContextGroups {
+			for _, c := range g.Contexts {
+				switch c.Type {
+				case xliffContextType:
+					context = c.Value
+				case xliffScreenshotType:
+					info.Screenshot = c.Value
+				}
+			}
+		}
+		info.Description = strings.Join(unit.Notes, "\n")
+		if unit.SizeUnit == "" || unit.SizeUnit == "char" {
+			info.MaxLength = unit.MaxWidth
+		}
+		key := ContextKey(context, msgKey)
+		if info != (MessageInfo{}) {
+			if source.Info == nil {
+				source.Info = make(map[string]MessageInfo)
+			}
+			source.Info[key] = info
+		}
+		source.Messages[key] = unit.Source
+		if unit.Target != nil {
+			target.Messages[key] = *unit.Target
+		}
+	}
+	if err := validateMessages(target.Locale+".xlf", target.Messages, source.Info); err != nil {
+		return nil, nil, err
+	}
+	return source, target, nil
+}
+
+func xliffLocale(lang string) string {
+	return strings.Replace(lang, "_", "-", -1)
+}
@@ -0,0 +1,84 @@
+package i18n
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestXLIFF(t *testing.T) {
+	source := &Catalog{Locale: "en", Messages: map[string]string{
+		"OPEN":         "Open",
+		"menu\x04OPEN": "Open",
+		"TERMS":        "Read the <link>terms</link>",
+		"NEW":          "New",
+	}}
+	target := &Catalog{Locale: "de", Messages: map[string]string{
+		"OPEN":         "Offen",
+		"menu\x04OPEN": "Öffnen",
+		"TERMS":        "Lies die <link>AGB</link>",
+	}}
+	var buf bytes.Buffer
+	if !assert.NoError(t, WriteXLIFF(&buf, source, target)) {
+		return
+	}
+	s := buf.String()
+	assert.True(t, strings.HasPrefix(s, `<?xml version="1.0" encoding="UTF-8"?>
+<xliff xmlns="urn:oasis:names:tc:xliff:document:1.2" version="1.2">
+  <file original="messages" datatype="plaintext" source-language="en" target-language="de">`), s)
+	assert.Contains(t, s, `
+      <trans-unit id="menu/OPEN" resname="OPEN">
+        <source>Open</source>
+        <target>Öffnen</target>
+        <context-group purpose="information">
+          <context context-type="x-context">menu</context>
+        </context-group>
+      </trans-unit>`)
+	assert.Contains(t, s, "<target>Lies die &lt;link&gt;AGB&lt;/link&gt;</target>")
+	assert.Contains(t, s, "<source>New</source>\n      </trans-unit>", "should leave out the target of untranslated units")
+
+	readSource, readTarget, err := ReadXLIFF(&buf)
+	if assert.NoError(t, err) {
+		assert.Equal(t, source, readSource)
+		assert.Equal(t, target, readTarget)
+	}
+
+	_, _, err = ReadXLIFF(strings.NewReader(`<xliff version="2.0"></xliff>`))
+	assert.EqualError(t, err, `Unsupported XLIFF version "2.0"`)
+	_, _, err = ReadXLIFF(strings.NewReader(`<xliff version="1.2"><file target-language="de"><body>
+<trans-unit id="X"><source>&lt;b&gt;x&lt;/b&gt;</source><target>&lt;b&gt;&lt;i&gt;x&lt;/b&gt;&lt;/i&gt;</target></trans-unit>
+</body></file></xliff>`))
+	assert.EqualError(t, err, "de.xlf: X: tag at offset 7: </b> crosses <i>")
+}
+
+func TestXLIFFMessageInfo(t *testing.T) {
+	source := &Catalog{Locale: "en", Messages: map[string]string{"SAVE": "Save"}, Info: map[string]MessageInfo{
+		"SAVE": {Description: "Button saving the form", MaxLength: 8, Screenshot: "form.png"},
+	}}
+	target := &Catalog{Locale: "de", Messages: map[string]string{"SAVE": "Sichern"}}
+	var buf bytes.Buffer
+	if !assert.NoError(t, WriteXLIFF(&buf, source, target)) {
+		return
+	}
+	assert.Contains(t, buf.String(), `
+      <trans-unit id="SAVE" resname="SAVE" maxwidth="8" size-unit="char">
+        <source>Save</source>
+        <target>Sichern</target>
+        <context-group purpose="information">
+          <context context-type="x-screenshot">form.png</context>
+        </context-group>
+        <note>Button saving the form</note>
+      </trans-unit>`)
+	readSource, readTarget, err := ReadXLIFF(&buf)
+	if assert.NoError(t, err) {
+		assert.Equal(t, source, readSource)
+		assert.Equal(t, target, readTarget)
+	}
+
+	_, _, err = ReadXLIFF(strings.NewReader(`<xliff version="1.2"><file target-language="de"><body>
+<trans-unit id="SAVE" maxwidth="8"><source>Save</source><target>Speichern</target></trans-unit>
+</body></file></xliff>`))
+	assert.EqualError(t, err, "de.xlf: SAVE: 9 characters, longer than the maximum of 8")
+}